@@ -0,0 +1,122 @@
+// ABOUTME: Agent capability profiles loaded from .tl/agents.yaml.
+// ABOUTME: A profile lists an agent's skills, allowed issue types and priority ceiling; tasks require skills via "skill:<name>" labels.
+
+package tl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const agentsFileName = "agents.yaml"
+
+// skillLabelPrefix marks a label as a skill the claiming agent must have.
+const skillLabelPrefix = "skill:"
+
+// AgentProfile describes what one agent is qualified to work on. Empty
+// fields impose no restriction.
+type AgentProfile struct {
+	Name   string   `yaml:"-" json:"name"`
+	Skills []string `yaml:"skills" json:"skills,omitempty"`
+	Types  []string `yaml:"types" json:"types,omitempty"`
+	// MaxPriority has the same meaning as --max-priority: the agent only
+	// takes tasks at this priority or more urgent.
+	MaxPriority *int `yaml:"max_priority" json:"max_priority,omitempty"`
+}
+
+type agentsFile struct {
+	Agents map[string]*AgentProfile `yaml:"agents"`
+}
+
+// loadAgents reads agent profiles keyed by name. A missing file yields none.
+func loadAgents(dir string) (map[string]*AgentProfile, error) {
+	path := filepath.Join(dir, agentsFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]*AgentProfile{}, nil
+		}
+		return nil, err
+	}
+	var file agentsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	profiles := make(map[string]*AgentProfile, len(file.Agents))
+	for name, profile := range file.Agents {
+		if profile == nil {
+			profile = &AgentProfile{}
+		}
+		profile.Name = name
+		profiles[name] = profile
+	}
+	return profiles, nil
+}
+
+// loadAgentProfile returns the named agent's profile. Without any profiles
+// every agent is unrestricted and the result is nil; once agents.yaml defines
+// some, an unlisted name is ErrNotFound, so a mistyped agent cannot end up
+// working the whole queue.
+func loadAgentProfile(dir, name string) (*AgentProfile, error) {
+	profiles, err := loadAgents(dir)
+	if err != nil || len(profiles) == 0 {
+		return nil, err
+	}
+	profile, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("agent profile %q in %s: %w", name, agentsFileName, ErrNotFound)
+	}
+	return profile, nil
+}
+
+// sortedAgents returns profiles ordered by name.
+func sortedAgents(profiles map[string]*AgentProfile) []*AgentProfile {
+	out := make([]*AgentProfile, 0, len(profiles))
+	for _, profile := range profiles {
+		out = append(out, profile)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// requiredSkills returns the skills an issue's "skill:" labels demand.
+func requiredSkills(issue *Issue) []string {
+	var skills []string
+	for _, label := range issue.Labels {
+		if skill, ok := strings.CutPrefix(label, skillLabelPrefix); ok && skill != "" {
+			skills = append(skills, skill)
+		}
+	}
+	return skills
+}
+
+// qualifies reports whether the agent may work on issue.
+func (p *AgentProfile) qualifies(issue *Issue) bool {
+	if p.MaxPriority != nil && issue.Priority > *p.MaxPriority {
+		return false
+	}
+	if len(p.Types) > 0 && !containsString(p.Types, string(issue.IssueType)) {
+		return false
+	}
+	for _, skill := range requiredSkills(issue) {
+		if !containsString(p.Skills, skill) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
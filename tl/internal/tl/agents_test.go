@@ -0,0 +1,196 @@
+// ABOUTME: Tests agent capability profiles and skill matching.
+// ABOUTME: Covers agents.yaml parsing, ready --agent, claim --next with a profile and the agents listing.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAgentsYAML = `agents:
+  frontend:
+    skills: [ui]
+    types: [task, bug]
+  infra:
+    skills: [ops, k8s]
+    max_priority: 1
+  docs:
+`
+
+func labeledIssueEvent(t *testing.T, id, title string, issueType IssueType, priority int, labels []string, ts time.Time) Event {
+	t.Helper()
+	data, err := json.Marshal(CreateEventData{Title: title, Status: string(StatusOpen), Priority: priority, IssueType: string(issueType), Labels: labels})
+	require.NoError(t, err)
+	return Event{Type: EventCreate, ID: id, Timestamp: ts, Actor: "test", Data: data}
+}
+
+func seedAgentsRepo(t *testing.T) string {
+	t.Helper()
+	ts := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	dir := seedCommandRepoWithEvents(t,
+		labeledIssueEvent(t, "tl-css", "Fix button", TypeBug, 2, []string{"skill:ui"}, ts),
+		labeledIssueEvent(t, "tl-k8s", "Scale cluster", TypeTask, 1, []string{"skill:ops", "skill:k8s"}, ts.Add(time.Minute)),
+		labeledIssueEvent(t, "tl-helm", "Tidy charts", TypeTask, 3, []string{"skill:k8s"}, ts.Add(2*time.Minute)),
+		labeledIssueEvent(t, "tl-any", "Triage inbox", TypeChore, 0, []string{"inbox"}, ts.Add(3*time.Minute)),
+		claimEventAt(t, "tl-helm", "infra", "", ts.Add(4*time.Minute)),
+	)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, agentsFileName), []byte(testAgentsYAML), 0644))
+	return dir
+}
+
+func TestLoadAgentsAndQualifies(t *testing.T) {
+	dir := seedAgentsRepo(t)
+	profiles, err := loadAgents(dir)
+	require.NoError(t, err)
+	require.Len(t, profiles, 3)
+	assert.Equal(t, "docs", profiles["docs"].Name)
+	require.NotNil(t, profiles["infra"].MaxPriority)
+	assert.Equal(t, 1, *profiles["infra"].MaxPriority)
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	frontend, infra, docs := profiles["frontend"], profiles["infra"], profiles["docs"]
+	assert.True(t, frontend.qualifies(graph.Tasks["tl-css"]))
+	assert.False(t, frontend.qualifies(graph.Tasks["tl-k8s"]), "missing skills")
+	assert.False(t, frontend.qualifies(graph.Tasks["tl-any"]), "chore is not an allowed type")
+	assert.True(t, infra.qualifies(graph.Tasks["tl-k8s"]))
+	assert.False(t, infra.qualifies(graph.Tasks["tl-helm"]), "P3 is below the priority ceiling")
+	assert.True(t, docs.qualifies(graph.Tasks["tl-any"]), "no skills required")
+	assert.False(t, docs.qualifies(graph.Tasks["tl-css"]))
+
+	_, err = loadAgentProfile(dir, "nobody")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	empty, err := loadAgents(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestReadyAgentFilter(t *testing.T) {
+	dir := seedAgentsRepo(t)
+	setReadyGlobals(t, dir, false)
+
+	run := func() string {
+		cmd := newTestCommand()
+		require.NoError(t, runReady(cmd, nil))
+		return cmd.OutOrStdout().(*bytes.Buffer).String()
+	}
+
+	readyAgent = "frontend"
+	assert.Equal(t, "tl-css P2 Fix button\n", run())
+	readyAgent = "infra"
+	assert.Equal(t, "tl-any P0 Triage inbox\ntl-k8s P1 Scale cluster\n", run(), "tasks without skill labels suit anyone")
+	readyAgent = "docs"
+	assert.Equal(t, "tl-any P0 Triage inbox\n", run())
+
+	readyAgent = "nobody"
+	assert.ErrorIs(t, runReady(newTestCommand(), nil), ErrNotFound)
+}
+
+func TestClaimNextHonorsAgentProfile(t *testing.T) {
+	dir := seedAgentsRepo(t)
+
+	result, err := claimTask(dir, claimRequest{Agent: "frontend", Filter: readyFilter{MaxPriority: -1}})
+	require.NoError(t, err)
+	assert.Equal(t, "tl-css", result.Issue.ID, "skips the more urgent tasks frontend is not qualified for")
+
+	_, err = claimTask(dir, claimRequest{Agent: "frontend", Filter: readyFilter{MaxPriority: -1}})
+	assert.ErrorIs(t, err, ErrNothingReady)
+
+	_, err = claimTask(dir, claimRequest{Agent: "unprofiled", Filter: readyFilter{MaxPriority: -1}})
+	assert.ErrorIs(t, err, ErrNotFound, "once profiles exist, an unlisted agent is refused rather than unrestricted")
+
+	result, err = claimTask(dir, claimRequest{Agent: "unprofiled", ID: "tl-any"})
+	require.NoError(t, err)
+	assert.Equal(t, "tl-any", result.Issue.ID, "claiming a named task does not need a profile")
+}
+
+func TestUnlistedDefaultAgentUsesWholeQueue(t *testing.T) {
+	dir := seedAgentsRepo(t)
+	newCmd := func(explicitAgent bool) (*cobra.Command, *bytes.Buffer, *bytes.Buffer) {
+		cmd := &cobra.Command{}
+		var stdout, stderr bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&stderr)
+		cmd.Flags().String("agent", "", "")
+		if explicitAgent {
+			require.NoError(t, cmd.Flags().Set("agent", "session"))
+		}
+		return cmd, &stdout, &stderr
+	}
+
+	setCommandGlobals(t, dir, false)
+	prevAgent, prevLimit := primeAgent, primeLimit
+	t.Cleanup(func() { primeAgent, primeLimit = prevAgent, prevLimit })
+	primeAgent, primeLimit = "session", 5
+	cmd, stdout, stderr := newCmd(false)
+	require.NoError(t, runPrime(cmd, nil), "a session hook must not break once agents.yaml exists")
+	assert.Contains(t, stdout.String(), "## Ready (3)")
+	assert.Equal(t, "warning: session has no profile in agents.yaml; using the unfiltered ready queue\n", stderr.String())
+
+	cmd, _, _ = newCmd(true)
+	assert.ErrorIs(t, runPrime(cmd, nil), ErrNotFound, "an agent named with --agent must be listed")
+
+	setClaimCommandGlobals(t, dir, false, "session")
+	claimNext = true
+	cmd, _, _ = newCmd(true)
+	assert.ErrorIs(t, runClaim(cmd, nil), ErrNotFound)
+	cmd, stdout, stderr = newCmd(false)
+	require.NoError(t, runClaim(cmd, nil))
+	assert.Equal(t, "Claimed tl-any by session\n", stdout.String())
+	assert.Contains(t, stderr.String(), "warning: session has no profile")
+}
+
+func TestAgentsWithoutProfilesAreUnrestricted(t *testing.T) {
+	dir := seedCommandRepoWithEvents(t, createIssueEvent(t, "tl-a", "Task", StatusOpen, 1, time.Now().UTC()))
+
+	profile, err := loadAgentProfile(dir, "anyone")
+	require.NoError(t, err)
+	assert.Nil(t, profile)
+
+	setReadyGlobals(t, dir, false)
+	readyAgent = "anyone"
+	cmd := newTestCommand()
+	require.NoError(t, runReady(cmd, nil))
+	assert.Equal(t, "tl-a P1 Task\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	result, err := claimTask(dir, claimRequest{Agent: "anyone", Filter: readyFilter{MaxPriority: -1}})
+	require.NoError(t, err)
+	assert.Equal(t, "tl-a", result.Issue.ID)
+}
+
+func TestAgentsListsProfilesWithClaims(t *testing.T) {
+	dir := seedAgentsRepo(t)
+	setCommandGlobals(t, dir, false)
+
+	cmd := newTestCommand()
+	require.NoError(t, runAgents(cmd, nil))
+	assert.Equal(t, "docs\n  no claims\n"+
+		"frontend skills: ui; types: task, bug\n  no claims\n"+
+		"infra skills: ops, k8s; max P1\n  tl-helm P3 Tidy charts\n",
+		cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	jsonOutput = true
+	cmd = newTestCommand()
+	require.NoError(t, runAgents(cmd, nil))
+	var rows []struct {
+		Name   string       `json:"name"`
+		Skills []string     `json:"skills"`
+		Claims []readyIssue `json:"claims"`
+	}
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(cmd.OutOrStdout().(*bytes.Buffer).Bytes()), &rows))
+	require.Len(t, rows, 3)
+	assert.Equal(t, "infra", rows[2].Name)
+	assert.Equal(t, []string{"ops", "k8s"}, rows[2].Skills)
+	require.Len(t, rows[2].Claims, 1)
+	assert.Equal(t, "tl-helm", rows[2].Claims[0].ID)
+}
@@ -119,6 +119,7 @@ func parseBeadsLine(line []byte) (*Issue, error) {
 		"defer_until":         true,
 		"labels":              true,
 		"dependencies":        true,
+		"comments":            true,
 		"pinned":              true,
 		"ephemeral":           true,
 	}
@@ -209,6 +210,10 @@ func parseBeadsLine(line []byte) (*Issue, error) {
 			if err := json.Unmarshal(value, &issue.Labels); err != nil {
 				return nil, fmt.Errorf("invalid labels: %w", err)
 			}
+		case "comments":
+			if err := json.Unmarshal(value, &issue.Comments); err != nil {
+				return nil, fmt.Errorf("invalid comments: %w", err)
+			}
 		case "dependencies":
 			var deps []*Dependency
 			if err := json.Unmarshal(value, &deps); err != nil {
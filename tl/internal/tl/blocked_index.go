@@ -0,0 +1,98 @@
+// ABOUTME: Incrementally maintained blocked set for graphs that change only through applyEvent.
+// ABOUTME: Each event re-derives just the issues whose blocking it can affect instead of the whole graph.
+
+package tl
+
+// blockedIndex caches the blocked set of a graph. Once a graph tracks its
+// blocked set, every change must go through applyEvent; code that mutates
+// issues in place must drop the index first (graph.blocked = nil) so
+// computeBlockedSet recomputes from the graph.
+type blockedIndex struct {
+	blocked map[string]bool
+}
+
+// trackBlocked computes the blocked set once and keeps it current as events
+// are applied. computeBlockedSet then answers from the index.
+func (graph *Graph) trackBlocked() {
+	graph.blocked = nil
+	graph.blocked = &blockedIndex{blocked: computeBlockedSet(graph)}
+}
+
+// replayEventsTracked replays events into a graph whose blocked set is
+// maintained incrementally from the first event on.
+func replayEventsTracked(events []Event) (*Graph, error) {
+	graph := newGraph()
+	graph.trackBlocked()
+	for _, event := range events {
+		if err := graph.applyEvent(event); err != nil {
+			return nil, err
+		}
+	}
+	return graph, nil
+}
+
+func (b *blockedIndex) snapshot() map[string]bool {
+	out := make(map[string]bool, len(b.blocked))
+	for id := range b.blocked {
+		out[id] = true
+	}
+	return out
+}
+
+func (b *blockedIndex) clone() *blockedIndex {
+	return &blockedIndex{blocked: b.snapshot()}
+}
+
+// affectedBy lists the issues whose blocking can change when issueID changes:
+// the issue itself, issues with an edge to it, and issues with an edge to one
+// of its parents (waits-for gates look at a parent's children). Callers
+// collect it both before and after the event so removed edges are covered.
+func (b *blockedIndex) affectedBy(graph *Graph, issueID string) []string {
+	affected := []string{issueID}
+	affected = append(affected, graph.RDeps[issueID]...)
+	if issue, ok := graph.Tasks[issueID]; ok {
+		for _, parentID := range parentIDs(issue) {
+			affected = append(affected, graph.RDeps[parentID]...)
+		}
+	}
+	return affected
+}
+
+// update re-derives the blocked state of seeds and of everything that inherits
+// blocking from them through parent-child edges. The region is cleared and
+// rebuilt from scratch so parent-child cycles cannot keep stale blocking alive.
+func (b *blockedIndex) update(graph *Graph, seeds []string) {
+	region := make(map[string]bool)
+	var queue []string
+	for _, id := range seeds {
+		if !region[id] {
+			region[id] = true
+			queue = append(queue, id)
+		}
+	}
+	for i := 0; i < len(queue); i++ {
+		for _, childID := range graph.childrenOf(queue[i]) {
+			if !region[childID] {
+				region[childID] = true
+				queue = append(queue, childID)
+			}
+		}
+	}
+
+	for id := range region {
+		delete(b.blocked, id)
+	}
+
+	var blockedNow []string
+	for _, id := range queue {
+		issue, ok := graph.Tasks[id]
+		if !ok {
+			continue
+		}
+		if directlyBlocked(graph, issue) || inheritsBlocked(issue, b.blocked) {
+			b.blocked[id] = true
+			blockedNow = append(blockedNow, id)
+		}
+	}
+	propagateBlocked(b.blocked, blockedNow, graph.childrenOf)
+}
@@ -0,0 +1,307 @@
+// ABOUTME: Tests that the incremental blocked index agrees with a full recomputation.
+// ABOUTME: Also benchmarks blocked-set and ready-queue latency on 5k and 50k synthetic tasks.
+
+package tl
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syntheticEvents builds a reproducible log of n tasks grouped under epics of
+// 25, with blocks edges to earlier tasks and roughly a third of tasks closed.
+func syntheticEvents(tb testing.TB, n int, seed int64) []Event {
+	tb.Helper()
+	rng := rand.New(rand.NewSource(seed))
+	ts := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var events []Event
+	add := func(eventType, id string, data any) {
+		raw, err := json.Marshal(data)
+		require.NoError(tb, err)
+		ts = ts.Add(time.Second)
+		events = append(events, Event{Type: eventType, ID: id, Timestamp: ts, Actor: "test", Data: raw})
+	}
+
+	epicID := ""
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("tl-%05d", i)
+		if i%25 == 0 {
+			epicID = id
+			add(EventCreate, id, CreateEventData{Title: id, Status: string(StatusOpen), IssueType: string(TypeEpic), Priority: rng.Intn(5)})
+			continue
+		}
+		add(EventCreate, id, CreateEventData{Title: id, Status: string(StatusOpen), Priority: rng.Intn(5)})
+		add(EventDepAdd, id, DepAddEventData{DependsOnID: epicID, DepType: string(DepParentChild)})
+		for j := rng.Intn(3); j > 0; j-- {
+			target := fmt.Sprintf("tl-%05d", rng.Intn(i))
+			if target == epicID {
+				continue
+			}
+			add(EventDepAdd, id, DepAddEventData{DependsOnID: target, DepType: string(DepBlocks)})
+		}
+	}
+	for i := 0; i < n; i++ {
+		if i%25 != 0 && rng.Intn(3) == 0 {
+			add(EventClose, fmt.Sprintf("tl-%05d", i), CloseEventData{Reason: "done"})
+		}
+	}
+	return events
+}
+
+// randomEvents mixes every event kind that can change blocking, including
+// conditional edges, gates, and parent-child cycles.
+func randomEvents(t *testing.T, rng *rand.Rand, ids []string, count int) []Event {
+	t.Helper()
+	ts := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	statuses := []Status{StatusOpen, StatusInProgress, StatusBlocked, StatusDeferred, StatusPinned}
+	depTypes := []DependencyType{DepBlocks, DepParentChild, DepConditionalBlocks, DepWaitsFor, DepRelated}
+	pick := func() string { return ids[rng.Intn(len(ids))] }
+
+	var events []Event
+	add := func(eventType, id string, data any) {
+		raw, err := json.Marshal(data)
+		require.NoError(t, err)
+		ts = ts.Add(time.Second)
+		events = append(events, Event{Type: eventType, ID: id, Timestamp: ts, Actor: "test", Data: raw})
+	}
+
+	for _, id := range ids {
+		add(EventCreate, id, CreateEventData{Title: id, Status: string(StatusOpen)})
+	}
+	for len(events) < count {
+		id := pick()
+		switch rng.Intn(7) {
+		case 0, 1:
+			target := pick()
+			if target == id {
+				continue
+			}
+			depType := depTypes[rng.Intn(len(depTypes))]
+			data := DepAddEventData{DependsOnID: target, DepType: string(depType)}
+			switch {
+			case depType == DepWaitsFor && rng.Intn(2) == 0:
+				data.Metadata, _ = json.Marshal(DepCondition{Gate: GateAnyChildren})
+			case depType == DepConditionalBlocks && rng.Intn(2) == 0:
+				data.Metadata, _ = json.Marshal(DepCondition{Label: "hot"})
+			}
+			add(EventDepAdd, id, data)
+		case 2:
+			add(EventDepRemove, id, DepRemoveEventData{DependsOnID: pick()})
+		case 3:
+			add(EventUpdate, id, UpdateEventData{Fields: map[string]json.RawMessage{
+				"status": json.RawMessage(`"` + string(statuses[rng.Intn(len(statuses))]) + `"`),
+			}})
+		case 4:
+			add(EventUpdate, id, UpdateEventData{Fields: map[string]json.RawMessage{"labels": json.RawMessage(`["hot"]`)}})
+		case 5:
+			add(EventClose, id, CloseEventData{Reason: "done"})
+		case 6:
+			add(EventReopen, id, struct{}{})
+		}
+	}
+	return events
+}
+
+func sortedKeys(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for id := range set {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestBlockedIndexMatchesFullRecompute(t *testing.T) {
+	ids := []string{"tl-a", "tl-b", "tl-c", "tl-d", "tl-e", "tl-f", "tl-g", "tl-h"}
+	for seed := int64(1); seed <= 40; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		events := randomEvents(t, rng, ids, 120)
+
+		tracked := newGraph()
+		tracked.trackBlocked()
+		plain := newGraph()
+		for i, event := range events {
+			require.NoError(t, tracked.applyEvent(event))
+			require.NoError(t, plain.applyEvent(event))
+			require.Equal(t, sortedKeys(computeBlockedSet(plain)), sortedKeys(computeBlockedSet(tracked)),
+				"seed %d, event %d (%s %s)", seed, i, event.Type, event.ID)
+		}
+	}
+}
+
+func TestBlockedIndexClearsParentChildCycle(t *testing.T) {
+	ts := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	graph, err := replayEventsTracked([]Event{
+		createIssueEvent(t, "tl-a", "A", StatusOpen, 1, ts),
+		createIssueEvent(t, "tl-b", "B", StatusOpen, 1, ts),
+		createIssueEvent(t, "tl-x", "X", StatusOpen, 1, ts),
+		depAddEvent(t, "tl-a", "tl-b", DepParentChild, ts),
+		depAddEvent(t, "tl-b", "tl-a", DepParentChild, ts),
+		statusUpdateEvent(t, "tl-a", StatusPinned, ts),
+		statusUpdateEvent(t, "tl-b", StatusPinned, ts),
+		depAddEvent(t, "tl-a", "tl-x", DepBlocks, ts),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tl-a", "tl-b"}, sortedKeys(computeBlockedSet(graph)))
+
+	require.NoError(t, graph.applyEvent(closeIssueEvent(t, "tl-x", "done", ts)))
+	assert.Empty(t, computeBlockedSet(graph), "cycle must not keep itself blocked once the outside blocker closes")
+}
+
+func TestComputeBlockedSetSynthetic(t *testing.T) {
+	events := syntheticEvents(t, 500, 7)
+	plain, err := replayEvents(events)
+	require.NoError(t, err)
+	tracked, err := replayEventsTracked(events)
+	require.NoError(t, err)
+	assert.Equal(t, sortedKeys(computeBlockedSet(plain)), sortedKeys(computeBlockedSet(tracked)))
+}
+
+func TestLoadGraphTracksBlockedSet(t *testing.T) {
+	events := syntheticEvents(t, 500, 3)
+	dir := seedCommandRepoWithEvents(t, events...)
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	require.NotNil(t, graph.blocked)
+
+	plain, err := replayEvents(events)
+	require.NoError(t, err)
+	assert.Equal(t, sortedKeys(computeBlockedSet(plain)), sortedKeys(computeBlockedSet(graph)))
+
+	// The index follows events applied after loading.
+	require.NoError(t, graph.applyEvent(closeIssueEvent(t, "tl-00001", "done", time.Now().UTC())))
+	require.NoError(t, plain.applyEvent(closeIssueEvent(t, "tl-00001", "done", time.Now().UTC())))
+	assert.Equal(t, sortedKeys(computeBlockedSet(plain)), sortedKeys(computeBlockedSet(graph)))
+}
+
+// readyTargetP95 is the PRD's latency budget for ready queries.
+const readyTargetP95 = 50 * time.Millisecond
+
+// reportP95 records the 95th percentile of the sampled durations in
+// milliseconds and returns it.
+func reportP95(b *testing.B, samples []time.Duration) time.Duration {
+	b.Helper()
+	if len(samples) == 0 {
+		return 0
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	idx := (len(samples)*95+99)/100 - 1
+	b.ReportMetric(float64(samples[idx])/float64(time.Millisecond), "p95-ms")
+	return samples[idx]
+}
+
+// checkP95 reports the 95th percentile and fails the benchmark when it is
+// over target.
+func checkP95(b *testing.B, samples []time.Duration, target time.Duration) {
+	b.Helper()
+	if p95 := reportP95(b, samples); p95 > target {
+		b.Errorf("p95 %v exceeds the %v target", p95, target)
+	}
+}
+
+var benchmarkSizes = []int{5000, 50000}
+
+func BenchmarkComputeBlockedSet(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		b.Run(fmt.Sprintf("%dk", n/1000), func(b *testing.B) {
+			graph, err := replayEvents(syntheticEvents(b, n, 1))
+			require.NoError(b, err)
+			samples := make([]time.Duration, 0, b.N)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				start := time.Now()
+				computeBlockedSet(graph)
+				samples = append(samples, time.Since(start))
+			}
+			reportP95(b, samples)
+		})
+	}
+}
+
+// BenchmarkReadyQueue covers what tl ready does once the graph is loaded:
+// reading the tracked blocked set and ordering the ready queue. It must stay
+// within the PRD budget at every size.
+func BenchmarkReadyQueue(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		b.Run(fmt.Sprintf("%dk", n/1000), func(b *testing.B) {
+			graph, err := replayEvents(syntheticEvents(b, n, 1))
+			require.NoError(b, err)
+			graph.trackBlocked()
+			now := time.Now()
+			samples := make([]time.Duration, 0, b.N)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				start := time.Now()
+				collectReadyIssues(graph, computeBlockedSet(graph), now)
+				samples = append(samples, time.Since(start))
+			}
+			checkP95(b, samples, readyTargetP95)
+		})
+	}
+}
+
+// BenchmarkLoadAndReady covers a whole tl ready from parsed events: replay,
+// building the blocked index as loadGraph does, and the ready queue. The PRD
+// sets its command latency target at 5k tasks; at 50k decoding the event
+// payloads alone exceeds it, so that size is reported but not checked.
+func BenchmarkLoadAndReady(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		b.Run(fmt.Sprintf("%dk", n/1000), func(b *testing.B) {
+			events := syntheticEvents(b, n, 1)
+			now := time.Now()
+			samples := make([]time.Duration, 0, b.N)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				start := time.Now()
+				graph, err := replayEvents(events)
+				if err != nil {
+					b.Fatal(err)
+				}
+				graph.trackBlocked()
+				collectReadyIssues(graph, computeBlockedSet(graph), now)
+				samples = append(samples, time.Since(start))
+			}
+			if n <= 5000 {
+				checkP95(b, samples, readyTargetP95)
+				return
+			}
+			reportP95(b, samples)
+		})
+	}
+}
+
+// BenchmarkBlockedIndexApply measures one incremental update: toggling an
+// epic's status re-derives the epic and its children only.
+func BenchmarkBlockedIndexApply(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		b.Run(fmt.Sprintf("%dk", n/1000), func(b *testing.B) {
+			graph, err := replayEventsTracked(syntheticEvents(b, n, 1))
+			require.NoError(b, err)
+			toggles := make([]Event, 2)
+			for i, status := range []Status{StatusDeferred, StatusOpen} {
+				data, err := json.Marshal(UpdateEventData{Fields: map[string]json.RawMessage{
+					"status": json.RawMessage(`"` + string(status) + `"`),
+				}})
+				require.NoError(b, err)
+				toggles[i] = Event{Type: EventUpdate, ID: "tl-00025", Actor: "test", Data: data}
+			}
+			samples := make([]time.Duration, 0, b.N)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				start := time.Now()
+				if err := graph.applyEvent(toggles[i%2]); err != nil {
+					b.Fatal(err)
+				}
+				samples = append(samples, time.Since(start))
+			}
+			reportP95(b, samples)
+		})
+	}
+}
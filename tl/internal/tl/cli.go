@@ -5,6 +5,7 @@ package tl
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -34,12 +35,27 @@ func init() {
 	rootCmd.AddCommand(reopenCmd)
 	rootCmd.AddCommand(readyCmd)
 	rootCmd.AddCommand(claimCmd)
+	rootCmd.AddCommand(heartbeatCmd)
+	rootCmd.AddCommand(releaseCmd)
+	rootCmd.AddCommand(logCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(commentCmd)
+	rootCmd.AddCommand(noteCmd)
+	rootCmd.AddCommand(contextCmd)
+	rootCmd.AddCommand(primeCmd)
+	rootCmd.AddCommand(editCmd)
+	rootCmd.AddCommand(deferCmd)
+	rootCmd.AddCommand(undeferCmd)
+	rootCmd.AddCommand(tickCmd)
+	rootCmd.AddCommand(templateCmd)
+	rootCmd.AddCommand(agentsCmd)
 	rootCmd.AddCommand(blockedCmd)
 	rootCmd.AddCommand(statsCmd)
 	rootCmd.AddCommand(depCmd)
 	rootCmd.AddCommand(importCmd)
 	rootCmd.AddCommand(exportCmd)
 	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(criticalPathCmd)
 }
 
 var initCmd = &cobra.Command{
@@ -108,6 +124,150 @@ var claimCmd = &cobra.Command{
 	},
 }
 
+var heartbeatCmd = &cobra.Command{
+	Use:   "heartbeat",
+	Short: "Renew the lease on a claimed task",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Return a claimed task to open",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show the event history of a task",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream new events and readiness changes as JSON lines",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var commentCmd = &cobra.Command{
+	Use:   "comment",
+	Short: "Discuss a task in a comment thread",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var commentAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a comment or reply to a task",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var commentListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show a task's comment thread",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var noteCmd = &cobra.Command{
+	Use:   "note",
+	Short: "Append a timestamped progress note to a task",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var contextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Print everything an agent needs to work on a task as one document",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var primeCmd = &cobra.Command{
+	Use:   "prime",
+	Short: "Print a workflow briefing for the start of an agent session",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var editCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit a task in $EDITOR as Markdown with YAML front matter",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var deferCmd = &cobra.Command{
+	Use:   "defer",
+	Short: "Park a task until a later time",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var undeferCmd = &cobra.Command{
+	Use:   "undefer",
+	Short: "Return a deferred task to open now",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var tickCmd = &cobra.Command{
+	Use:   "tick",
+	Short: "Create due instances of recurring schedules",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Instantiate and extract task templates",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var templateApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create an epic and its tasks from a template",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var templateExtractCmd = &cobra.Command{
+	Use:   "extract",
+	Short: "Write a template from an existing epic",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var agentsCmd = &cobra.Command{
+	Use:   "agents",
+	Short: "List agent profiles and their current claims",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
 var blockedCmd = &cobra.Command{
 	Use:   "blocked",
 	Short: "Show blocked tasks",
@@ -148,6 +308,30 @@ var depRemoveCmd = &cobra.Command{
 	},
 }
 
+var depCyclesCmd = &cobra.Command{
+	Use:   "cycles",
+	Short: "Find dependency cycles",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var depImpactCmd = &cobra.Command{
+	Use:   "impact",
+	Short: "Preview what closing a task would unblock",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
+var depTreeCmd = &cobra.Command{
+	Use:   "tree",
+	Short: "Show a task's dependencies as a tree",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
 var importCmd = &cobra.Command{
 	Use:   "import",
 	Short: "Import tasks",
@@ -172,14 +356,41 @@ var syncCmd = &cobra.Command{
 	},
 }
 
+var criticalPathCmd = &cobra.Command{
+	Use:   "critical-path",
+	Short: "Show the critical path and slack of open tasks",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("not implemented")
+	},
+}
+
 func init() {
 	depCmd.AddCommand(depAddCmd)
 	depCmd.AddCommand(depRemoveCmd)
+	depCmd.AddCommand(depCyclesCmd)
+	depCmd.AddCommand(depImpactCmd)
+	depCmd.AddCommand(depTreeCmd)
+	templateCmd.AddCommand(templateApplyCmd)
+	templateCmd.AddCommand(templateExtractCmd)
+	commentCmd.AddCommand(commentAddCmd)
+	commentCmd.AddCommand(commentListCmd)
 }
 
 func Execute() {
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	classifyUsageErrors(rootCmd)
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		// cobra reports unknown subcommands with an untyped error.
+		if strings.HasPrefix(err.Error(), "unknown command ") {
+			err = &classifiedError{err: err, class: ErrValidation}
+		}
+		// Agents parse --json output from stdout; humans read stderr.
+		if jsonOutput {
+			writeError(os.Stdout, err, true)
+		} else {
+			writeError(os.Stderr, err, false)
+		}
+		os.Exit(exitCode(err))
 	}
 }
@@ -0,0 +1,114 @@
+// ABOUTME: Agents command — lists agent profiles from .tl/agents.yaml with the tasks each currently holds.
+// ABOUTME: Implements `tl agents`; claims are in-progress tasks assigned to the agent.
+
+package tl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// agentRow is one profile plus the tasks the agent currently holds.
+type agentRow struct {
+	*AgentProfile
+	Claims []readyIssue `json:"claims"`
+}
+
+func init() {
+	agentsCmd.Args = cobra.NoArgs
+	agentsCmd.RunE = runAgents
+}
+
+func runAgents(cmd *cobra.Command, args []string) error {
+	dir, err := tlDir(GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag})
+	if err != nil {
+		return err
+	}
+
+	profiles, err := loadAgents(dir)
+	if err != nil {
+		return err
+	}
+	graph, err := loadGraph(dir)
+	if err != nil {
+		return err
+	}
+
+	claims := make(map[string][]*Issue)
+	for _, issue := range graph.Tasks {
+		if issue.Status == StatusInProgress && issue.Assignee != "" {
+			claims[issue.Assignee] = append(claims[issue.Assignee], issue)
+		}
+	}
+
+	rows := make([]agentRow, 0, len(profiles))
+	for _, profile := range sortedAgents(profiles) {
+		held := claims[profile.Name]
+		sortIssues(held)
+		row := agentRow{AgentProfile: profile, Claims: make([]readyIssue, 0, len(held))}
+		for _, issue := range held {
+			row.Claims = append(row.Claims, newReadyIssue(issue))
+		}
+		rows = append(rows, row)
+	}
+
+	if jsonOutput {
+		data, err := json.Marshal(rows)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	w := cmd.OutOrStdout()
+	if len(rows) == 0 {
+		fmt.Fprintf(w, "No agent profiles (define them in %s)\n", agentsFileName)
+		return nil
+	}
+	for _, row := range rows {
+		fmt.Fprintln(w, formatAgentProfile(row.AgentProfile))
+		if len(row.Claims) == 0 {
+			fmt.Fprintln(w, "  no claims")
+		}
+		for _, claim := range row.Claims {
+			fmt.Fprintf(w, "  %s P%d %s\n", claim.ID, claim.Priority, strings.TrimSpace(claim.Title))
+		}
+	}
+	return nil
+}
+
+// formatAgentProfile renders e.g. "frontend skills: ui, css; types: task; max P2".
+func formatAgentProfile(p *AgentProfile) string {
+	var parts []string
+	if len(p.Skills) > 0 {
+		parts = append(parts, "skills: "+strings.Join(p.Skills, ", "))
+	}
+	if len(p.Types) > 0 {
+		parts = append(parts, "types: "+strings.Join(p.Types, ", "))
+	}
+	if p.MaxPriority != nil {
+		parts = append(parts, fmt.Sprintf("max P%d", *p.MaxPriority))
+	}
+	if len(parts) == 0 {
+		return p.Name
+	}
+	return p.Name + " " + strings.Join(parts, "; ")
+}
+
+// defaultAgentProfile loads the profile for a command whose --agent defaults
+// to the actor. An agent named with --agent must be listed in agents.yaml, as
+// with `tl ready --agent`; an unlisted default actor gets the unfiltered queue
+// and a warning, so session hooks keep working once profiles exist.
+func defaultAgentProfile(cmd *cobra.Command, dir, agent string) (*AgentProfile, error) {
+	profile, err := loadAgentProfile(dir, agent)
+	if errors.Is(err, ErrNotFound) && !cmd.Flags().Changed("agent") {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s has no profile in %s; using the unfiltered ready queue\n", agent, agentsFileName)
+		return nil, nil
+	}
+	return profile, err
+}
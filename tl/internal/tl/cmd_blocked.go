@@ -1,5 +1,5 @@
 // ABOUTME: Blocked command implementation for listing currently unworkable tasks.
-// ABOUTME: Shows each blocked issue and direct unresolved dependency IDs that keep it blocked.
+// ABOUTME: Shows each blocked issue, the dependency IDs that keep it blocked, and any active edge conditions.
 
 package tl
 
@@ -13,10 +13,18 @@ import (
 )
 
 type blockedIssue struct {
-	ID       string   `json:"id"`
-	Title    string   `json:"title"`
-	Status   Status   `json:"status"`
-	Blockers []string `json:"blockers"`
+	ID         string            `json:"id"`
+	Title      string            `json:"title"`
+	Status     Status            `json:"status"`
+	Blockers   []string          `json:"blockers"`
+	Conditions []activeCondition `json:"conditions,omitempty"`
+}
+
+// activeCondition explains a conditional-blocks or waits-for edge that is currently holding an issue back.
+type activeCondition struct {
+	DependsOnID string         `json:"depends_on_id"`
+	Type        DependencyType `json:"type"`
+	Reason      string         `json:"reason"`
 }
 
 func init() {
@@ -55,6 +63,9 @@ func runBlocked(cmd *cobra.Command, args []string) error {
 			strings.TrimSpace(row.Title),
 			strings.Join(row.Blockers, " "),
 		)
+		for _, cond := range row.Conditions {
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s %s: %s\n", cond.Type, cond.DependsOnID, cond.Reason)
+		}
 	}
 
 	return nil
@@ -85,36 +96,35 @@ func collectBlockedIssues(graph *Graph, blockedSet map[string]bool) []blockedIss
 	})
 
 	for _, issue := range issues {
+		blockers, conditions := blockersForIssue(issue, graph, blockedSet)
 		rows = append(rows, blockedIssue{
-			ID:       issue.ID,
-			Title:    issue.Title,
-			Status:   issue.Status,
-			Blockers: blockerIDsForIssue(issue, graph, blockedSet),
+			ID:         issue.ID,
+			Title:      issue.Title,
+			Status:     issue.Status,
+			Blockers:   blockers,
+			Conditions: conditions,
 		})
 	}
 
 	return rows
 }
 
-func blockerIDsForIssue(issue *Issue, graph *Graph, blockedSet map[string]bool) []string {
+func blockersForIssue(issue *Issue, graph *Graph, blockedSet map[string]bool) ([]string, []activeCondition) {
 	ids := make(map[string]struct{})
+	var conditions []activeCondition
 
 	for _, dep := range issue.Dependencies {
-		if dep == nil || !dep.Type.AffectsReadyWork() {
-			continue
-		}
-		depIssue, ok := graph.Tasks[dep.DependsOnID]
-		if !ok {
+		active, reason := dependencyBlocks(graph, dep, blockedSet)
+		if !active {
 			continue
 		}
-
-		if issueStatusBlocksReady(depIssue.Status) {
-			ids[dep.DependsOnID] = struct{}{}
-			continue
-		}
-
-		if dep.Type == DepParentChild && blockedSet[dep.DependsOnID] {
-			ids[dep.DependsOnID] = struct{}{}
+		ids[dep.DependsOnID] = struct{}{}
+		if reason != "" {
+			conditions = append(conditions, activeCondition{
+				DependsOnID: dep.DependsOnID,
+				Type:        dep.Type,
+				Reason:      reason,
+			})
 		}
 	}
 
@@ -123,5 +133,5 @@ func blockerIDsForIssue(issue *Issue, graph *Graph, blockedSet map[string]bool)
 		blockers = append(blockers, id)
 	}
 	sort.Strings(blockers)
-	return blockers
+	return blockers, conditions
 }
@@ -40,3 +40,33 @@ func TestBlockedShowsBlockingIssueIDsTextAndJSON(t *testing.T) {
 	assert.Equal(t, "tl-target", rows[0].ID)
 	assert.Equal(t, []string{"tl-blocker"}, rows[0].Blockers)
 }
+
+func TestBlockedExplainsActiveCondition(t *testing.T) {
+	ts := time.Date(2026, 1, 4, 10, 0, 0, 0, time.UTC)
+	dir := seedCommandRepoWithEvents(
+		t,
+		createIssueEvent(t, "tl-tests", "Tests", StatusOpen, 0, ts),
+		createIssueEvent(t, "tl-deploy", "Deploy", StatusOpen, 1, ts.Add(time.Minute)),
+		conditionalDepAddEvent(t, "tl-deploy", "tl-tests", DepConditionalBlocks, DepCondition{CloseReason: "failed"}, ts.Add(2*time.Minute)),
+		closeIssueEvent(t, "tl-tests", "failed", ts.Add(3*time.Minute)),
+	)
+
+	setCommandGlobals(t, dir, false)
+	cmd := newTestCommand()
+	require.NoError(t, runBlocked(cmd, nil))
+
+	text := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, text, "tl-deploy [open] Deploy (blocked by: tl-tests)\n")
+	assert.Contains(t, text, "  conditional-blocks tl-tests: tl-tests closed with reason \"failed\"\n")
+
+	setCommandGlobals(t, dir, true)
+	cmd = newTestCommand()
+	require.NoError(t, runBlocked(cmd, nil))
+
+	var rows []blockedIssue
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(cmd.OutOrStdout().(*bytes.Buffer).Bytes()), &rows))
+	require.Len(t, rows, 1)
+	require.Len(t, rows[0].Conditions, 1)
+	assert.Equal(t, DepConditionalBlocks, rows[0].Conditions[0].Type)
+	assert.Equal(t, "tl-tests", rows[0].Conditions[0].DependsOnID)
+}
@@ -1,27 +1,85 @@
 // ABOUTME: Claim command — atomically transitions an open task to in_progress under flock.
-// ABOUTME: Implements `tl claim <id>` ensuring only one agent can claim a task concurrently.
+// ABOUTME: Implements `tl claim <id>`, `--next` (honoring agent profiles) and `--steal`; one agent wins, re-claims are idempotent.
 
 package tl
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
-var claimAgent string
+var (
+	claimAgent       string
+	claimLease       string
+	claimNext        bool
+	claimLabel       string
+	claimType        string
+	claimMaxPriority int
+	claimSteal       bool
+	claimReason      string
+)
 
 func init() {
-	claimCmd.Args = cobra.ExactArgs(1)
-	claimCmd.Flags().StringVar(&claimAgent, "agent", resolveActor(), "Agent claiming the task")
+	claimCmd.Args = func(cmd *cobra.Command, args []string) error {
+		if claimNext {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	}
+	claimCmd.Flags().StringVar(&claimAgent, "agent", resolveActor(), "Agent claiming the task; with --next its agents.yaml profile limits which tasks qualify")
+	claimCmd.Flags().StringVar(&claimLease, "lease", "", "Release the claim unless renewed by `tl heartbeat` within this duration (e.g. 30m)")
+	claimCmd.Flags().BoolVar(&claimNext, "next", false, "Claim the top ready task instead of a given ID")
+	claimCmd.Flags().StringVar(&claimLabel, "label", "", "With --next: only tasks with this label")
+	claimCmd.Flags().StringVar(&claimType, "type", "", "With --next: only tasks of this issue type")
+	claimCmd.Flags().IntVar(&claimMaxPriority, "max-priority", -1, "With --next: only tasks at this priority or more urgent (-1 = no limit)")
+	claimCmd.Flags().BoolVar(&claimSteal, "steal", false, "Take over a task claimed by another agent (requires --reason)")
+	claimCmd.Flags().StringVar(&claimReason, "reason", "", "Why the task is being taken over (recorded in the handoff event)")
 	claimCmd.RunE = runClaim
 }
 
+// claimRequest describes one claim. An empty ID claims the top ready task
+// that matches Filter. Steal allows taking over another agent's claim.
+type claimRequest struct {
+	ID     string
+	Agent  string
+	Lease  string
+	Filter readyFilter
+	Steal  bool
+	Reason string
+	// Unprofiled lets Agent pick from the whole queue even when agents.yaml
+	// does not list it.
+	Unprofiled bool
+}
+
+type claimResult struct {
+	Issue       Issue
+	Expired     *LeaseExpiredEventData
+	Handoff     *HandoffEventData
+	AlreadyHeld bool
+}
+
 func runClaim(cmd *cobra.Command, args []string) error {
-	id := args[0]
-	agent := claimAgent
-	if agent == "" {
-		agent = resolveActor()
+	req := claimRequest{
+		Agent:  claimAgent,
+		Lease:  claimLease,
+		Filter: readyFilter{Label: claimLabel, Type: claimType, MaxPriority: claimMaxPriority},
+		Steal:  claimSteal,
+		Reason: strings.TrimSpace(claimReason),
+	}
+	if req.Steal && req.Reason == "" {
+		return validationError("--steal requires --reason")
+	}
+	if req.Steal && claimNext {
+		return validationError("--steal cannot be combined with --next")
+	}
+	if req.Agent == "" {
+		req.Agent = resolveActor()
+	}
+	if !claimNext {
+		req.ID = args[0]
 	}
 
 	opts := GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag}
@@ -30,36 +88,186 @@ func runClaim(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	var claimed Issue
+	if claimNext {
+		if req.Filter.Profile, err = defaultAgentProfile(cmd, dir, req.Agent); err != nil {
+			return err
+		}
+		req.Unprofiled = req.Filter.Profile == nil
+	}
+
+	result, err := claimTask(dir, req)
+	if err != nil {
+		return err
+	}
+	claimed := result.Issue
+
+	if opts.JSON {
+		return printIssueJSON(cmd, &claimed)
+	}
+
+	w := cmd.OutOrStdout()
+	if result.AlreadyHeld {
+		fmt.Fprintf(w, "Already claimed %s by %s", claimed.ID, claimed.Assignee)
+		if claimed.Lease != nil && req.Lease != "" {
+			fmt.Fprintf(w, " (lease renewed until %s)", claimed.Lease.ExpiresAt.Format(time.RFC3339))
+		}
+		fmt.Fprintln(w)
+		return nil
+	}
+	if result.Handoff != nil {
+		fmt.Fprintf(w, "Handed off %s from %s to %s: %s\n", claimed.ID, result.Handoff.From, result.Handoff.To, result.Handoff.Reason)
+		return nil
+	}
+	if result.Expired != nil {
+		fmt.Fprintf(w, "Lease held by %s expired at %s\n", result.Expired.Agent, result.Expired.ExpiredAt.Format(time.RFC3339))
+	}
+	fmt.Fprintf(w, "Claimed %s by %s", claimed.ID, claimed.Assignee)
+	if claimed.Lease != nil {
+		fmt.Fprintf(w, " (lease %s, expires %s)", claimed.Lease.Duration, claimed.Lease.ExpiresAt.Format(time.RFC3339))
+	}
+	fmt.Fprintln(w)
+	return nil
+}
+
+// claimTask selects and claims a task inside a single mutate, so no other
+// agent can take it between selection and claim.
+func claimTask(dir string, req claimRequest) (claimResult, error) {
+	var result claimResult
+	if req.Lease != "" {
+		if _, err := parseLeaseDuration(req.Lease); err != nil {
+			return result, err
+		}
+	}
+
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return result, err
+	}
+	strategy, err := resolveStrategy("", cfg)
+	if err != nil {
+		return result, err
+	}
+	cfg.Ready.Strategy = strategy
+	if req.ID == "" && req.Filter.Profile == nil && !req.Unprofiled {
+		// An agent with a profile only picks tasks it is qualified for.
+		if req.Filter.Profile, err = loadAgentProfile(dir, req.Agent); err != nil {
+			return result, err
+		}
+	}
+
 	err = mutate(dir, func(g *Graph) ([]Event, error) {
+		now := time.Now()
+		id := req.ID
+		if id == "" {
+			candidates, err := rankedReadyIssues(g, req.Filter, cfg, now)
+			if err != nil {
+				return nil, err
+			}
+			if len(candidates) == 0 {
+				return nil, ErrNothingReady
+			}
+			// A label limit may rule out the top task but not the next one.
+			var wipErr error
+			for _, candidate := range candidates {
+				if err := checkWIP(g, cfg.WIP, candidate, req.Agent, now); err != nil {
+					if wipErr == nil {
+						wipErr = err
+					}
+					continue
+				}
+				id = candidate.ID
+				break
+			}
+			if id == "" {
+				return nil, wipErr
+			}
+		}
+
 		issue, ok := g.Tasks[id]
 		if !ok {
 			return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
 		}
-		if issue.Status != StatusOpen {
-			return nil, fmt.Errorf("task %s is not open (status: %s)", id, issue.Status)
+
+		var events []Event
+		switch {
+		case leaseExpired(issue, now):
+			result.Expired = &LeaseExpiredEventData{Agent: issue.Assignee, ExpiredAt: issue.Lease.ExpiresAt}
+			evt, err := newEvent(EventLeaseExpired, id, *result.Expired)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, evt)
+		case issue.Status == StatusInProgress && issue.Assignee == req.Agent:
+			result.AlreadyHeld = true
+			if req.Lease == "" {
+				result.Issue = *issue
+				return nil, nil
+			}
+			// Re-claiming with a lease renews it, like a heartbeat.
+			evt, err := newEvent(EventHeartbeat, id, HeartbeatEventData{Agent: req.Agent, Lease: req.Lease})
+			if err != nil {
+				return nil, err
+			}
+			if err := g.applyEvent(evt); err != nil {
+				return nil, err
+			}
+			result.Issue = *issue
+			return []Event{evt}, nil
+		case issue.Status == StatusInProgress && issue.Assignee != "" && req.Steal:
+			result.Handoff = &HandoffEventData{From: issue.Assignee, To: req.Agent, Reason: req.Reason, Lease: req.Lease}
+		case issue.Status == StatusInProgress && issue.Assignee != "":
+			return nil, conflictError("task %s is not open: already claimed by %s (use --steal --reason to take it over)", id, issue.Assignee)
+		case deferLapsed(issue, now):
+			// The deferral has passed; the task is open again.
+		case issue.Status != StatusOpen:
+			return nil, conflictError("task %s is not open (status: %s)", id, issue.Status)
+		}
+		if err := checkWIP(g, cfg.WIP, issue, req.Agent, now); err != nil {
+			return nil, err
 		}
 
-		evt, err := newEvent(EventClaim, id, ClaimEventData{Agent: agent})
+		var evt Event
+		var err error
+		if result.Handoff != nil {
+			evt, err = newEvent(EventHandoff, id, *result.Handoff)
+		} else {
+			evt, err = newEvent(EventClaim, id, ClaimEventData{Agent: req.Agent, Lease: req.Lease})
+		}
 		if err != nil {
 			return nil, err
 		}
+		events = append(events, evt)
 
-		issue.Status = StatusInProgress
-		issue.Assignee = agent
-		issue.UpdatedAt = evt.Timestamp
-		claimed = *issue
-
-		return []Event{evt}, nil
+		for _, evt := range events {
+			if err := g.applyEvent(evt); err != nil {
+				return nil, err
+			}
+		}
+		result.Issue = *issue
+		return events, nil
 	})
+	return result, err
+}
+
+// rankedReadyIssues returns the ready issues matching filter, best first
+// under the configured strategy.
+func rankedReadyIssues(g *Graph, filter readyFilter, cfg Config, now time.Time) ([]*Issue, error) {
+	var matched []*Issue
+	for _, issue := range collectReadyIssues(g, computeBlockedSet(g), now) {
+		if filter.matches(issue, now) {
+			matched = append(matched, issue)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, nil
+	}
+	scored, err := scoreReady(g, matched, cfg.Ready.Strategy, cfg, now)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	if opts.JSON {
-		return printIssueJSON(cmd, &claimed)
+	ranked := make([]*Issue, len(scored))
+	for i, s := range scored {
+		ranked[i] = s.Issue
 	}
-
-	fmt.Fprintf(cmd.OutOrStdout(), "Claimed %s by %s\n", claimed.ID, claimed.Assignee)
-	return nil
+	return ranked, nil
 }
@@ -1,5 +1,5 @@
 // ABOUTME: Tests for tl claim command — verifies open->in_progress claim behavior and output.
-// ABOUTME: Covers not-found/not-open errors and concurrent claim contention with exactly one winner.
+// ABOUTME: Covers not-found/not-open errors, idempotent re-claims, --next selection, and concurrent contention with one winner.
 
 package tl
 
@@ -7,9 +7,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -75,17 +77,17 @@ func TestClaimNotOpen(t *testing.T) {
 
 func TestClaimConcurrentOnlyOneSucceeds(t *testing.T) {
 	dir := seedIssue(t, "tl-claim-05", "Contended", StatusOpen)
-	setClaimCommandGlobals(t, dir, false, "agent-race")
 
 	start := make(chan struct{})
 	errCh := make(chan error, 2)
 	var wg sync.WaitGroup
-	for i := 0; i < 2; i++ {
+	for _, agent := range []string{"agent-race-1", "agent-race-2"} {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			<-start
-			errCh <- runClaim(newClaimCommand(t), []string{"tl-claim-05"})
+			_, err := claimTask(dir, claimRequest{ID: "tl-claim-05", Agent: agent, Filter: readyFilter{MaxPriority: -1}})
+			errCh <- err
 		}()
 	}
 
@@ -116,7 +118,7 @@ func TestClaimConcurrentOnlyOneSucceeds(t *testing.T) {
 	issue := g.Tasks["tl-claim-05"]
 	require.NotNil(t, issue)
 	assert.Equal(t, StatusInProgress, issue.Status)
-	assert.Equal(t, "agent-race", issue.Assignee)
+	assert.Contains(t, []string{"agent-race-1", "agent-race-2"}, issue.Assignee)
 }
 
 func newClaimCommand(t *testing.T) *cobra.Command {
@@ -133,13 +135,83 @@ func setClaimCommandGlobals(t *testing.T, dir string, json bool, agent string) {
 	prevJSON := jsonOutput
 	prevDir := tlDirFlag
 	prevAgent := claimAgent
+	prevLease := claimLease
+	prevNext, prevLabel, prevType, prevMaxPriority := claimNext, claimLabel, claimType, claimMaxPriority
+	prevSteal, prevReason := claimSteal, claimReason
 	t.Cleanup(func() {
 		jsonOutput = prevJSON
 		tlDirFlag = prevDir
 		claimAgent = prevAgent
+		claimLease = prevLease
+		claimNext, claimLabel, claimType, claimMaxPriority = prevNext, prevLabel, prevType, prevMaxPriority
+		claimSteal, claimReason = prevSteal, prevReason
 	})
 
 	jsonOutput = json
 	tlDirFlag = dir
 	claimAgent = agent
+	claimLease = ""
+	claimNext = false
+	claimLabel = ""
+	claimType = ""
+	claimMaxPriority = -1
+	claimSteal = false
+	claimReason = ""
+}
+
+func TestClaimSameAgentIsIdempotent(t *testing.T) {
+	dir := seedIssue(t, "tl-claim-06", "Mine", StatusOpen)
+	setClaimCommandGlobals(t, dir, false, "agent-1")
+
+	require.NoError(t, runClaim(newClaimCommand(t), []string{"tl-claim-06"}))
+	cmd := newClaimCommand(t)
+	require.NoError(t, runClaim(cmd, []string{"tl-claim-06"}))
+	assert.Equal(t, "Already claimed tl-claim-06 by agent-1\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	claimAgent = "agent-2"
+	err := runClaim(newClaimCommand(t), []string{"tl-claim-06"})
+	assert.ErrorContains(t, err, "already claimed by agent-1")
+
+	events, err := readEvents(filepath.Join(dir, eventsFileName))
+	require.NoError(t, err)
+	assert.Len(t, events, 2, "re-claim must not append another claim event")
+}
+
+func TestClaimNextPicksTopMatchingReadyTask(t *testing.T) {
+	ts := time.Date(2026, 2, 7, 9, 0, 0, 0, time.UTC)
+	labeled, err := json.Marshal(CreateEventData{Title: "Frontend", Status: string(StatusOpen), Priority: 2, IssueType: string(TypeBug), Labels: []string{"ui"}})
+	require.NoError(t, err)
+	dir := seedCommandRepoWithEvents(t,
+		createIssueEvent(t, "tl-top", "Top", StatusOpen, 0, ts),
+		createIssueEvent(t, "tl-blocker", "Blocker", StatusOpen, 1, ts.Add(time.Minute)),
+		createIssueEvent(t, "tl-waiting", "Waiting", StatusOpen, 0, ts.Add(2*time.Minute)),
+		Event{Type: EventCreate, ID: "tl-ui", Timestamp: ts.Add(3 * time.Minute), Actor: "test", Data: labeled},
+		depAddEvent(t, "tl-waiting", "tl-blocker", DepBlocks, ts.Add(4*time.Minute)),
+	)
+	setClaimCommandGlobals(t, dir, true, "agent-1")
+	claimNext = true
+
+	assert.NoError(t, claimCmd.Args(claimCmd, nil))
+	assert.Error(t, claimCmd.Args(claimCmd, []string{"tl-top"}))
+
+	cmd := newClaimCommand(t)
+	require.NoError(t, runClaim(cmd, nil))
+	var issue Issue
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &issue))
+	assert.Equal(t, "tl-top", issue.ID)
+	assert.Equal(t, StatusInProgress, issue.Status)
+
+	claimLabel = "ui"
+	claimType = string(TypeBug)
+	cmd = newClaimCommand(t)
+	require.NoError(t, runClaim(cmd, nil))
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &issue))
+	assert.Equal(t, "tl-ui", issue.ID)
+
+	claimLabel, claimType = "", ""
+	claimMaxPriority = 0
+	err = runClaim(newClaimCommand(t), nil)
+	assert.ErrorIs(t, err, ErrNothingReady, "tl-waiting is P0 but blocked")
+	assert.Equal(t, exitNothingReady, exitCode(err))
+	assert.Equal(t, exitNotFound, exitCode(ErrNotFound))
 }
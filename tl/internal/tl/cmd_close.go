@@ -1,17 +1,20 @@
 // ABOUTME: Close and reopen commands — transition tasks to closed/open status.
-// ABOUTME: Implements `tl close <id>` and `tl reopen <id>` with transition validation.
+// ABOUTME: Implements `tl close <id>` (epic guards, cascade, auto-close, newly-ready handoff) and `tl reopen <id>`.
 
 package tl
 
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	closeCmd.Flags().String("reason", "", "Reason for closing")
+	closeCmd.Flags().Bool("cascade", false, "Also close the open descendants of an epic")
 
 	closeCmd.RunE = runClose
 	reopenCmd.RunE = runReopen
@@ -19,7 +22,7 @@ func init() {
 
 func runClose(cmd *cobra.Command, args []string) error {
 	if len(args) < 1 {
-		return fmt.Errorf("usage: tl close <id>")
+		return validationError("usage: tl close <id>")
 	}
 	id := args[0]
 
@@ -29,8 +32,16 @@ func runClose(cmd *cobra.Command, args []string) error {
 	}
 
 	reason, _ := cmd.Flags().GetString("reason")
+	cascade, _ := cmd.Flags().GetBool("cascade")
+
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return err
+	}
 
 	var updatedIssue Issue
+	var cascaded, autoClosed []string
+	var newlyReady []readyIssue
 	err = mutate(dir, func(g *Graph) ([]Event, error) {
 		issue, ok := g.Tasks[id]
 		if !ok {
@@ -40,34 +51,42 @@ func runClose(cmd *cobra.Command, args []string) error {
 		if err := validateTransition(issue.Status, StatusClosed); err != nil {
 			return nil, err
 		}
+		before := cloneGraph(g)
 
-		evt, err := newEvent(EventClose, id, CloseEventData{Reason: reason})
+		result, err := closeIssue(g, cfg, issue, reason, cascade)
 		if err != nil {
 			return nil, err
 		}
-
-		// Apply close state to in-memory issue for post-mutate capture
-		issue.Status = StatusClosed
-		issue.CloseReason = reason
-		closedAt := evt.Timestamp
-		issue.ClosedAt = &closedAt
-		issue.UpdatedAt = evt.Timestamp
 		updatedIssue = *issue
+		cascaded, autoClosed = result.Cascaded, result.AutoClosed
+		newlyReady = newlyReadyIssues(before, g, time.Now())
 
-		return []Event{evt}, nil
+		return result.Events, nil
 	})
 	if err != nil {
 		return err
 	}
 
 	if jsonOutput {
-		out, err := json.MarshalIndent(updatedIssue, "", "  ")
+		out, err := json.MarshalIndent(struct {
+			Issue
+			NewlyReady []readyIssue `json:"newly_ready"`
+		}{updatedIssue, newlyReady}, "", "  ")
 		if err != nil {
 			return err
 		}
 		fmt.Fprintln(cmd.OutOrStdout(), string(out))
 	} else {
+		for _, childID := range cascaded {
+			fmt.Fprintf(cmd.OutOrStdout(), "Closed %s (cascade)\n", childID)
+		}
 		fmt.Fprintf(cmd.OutOrStdout(), "Closed %s\n", id)
+		for _, epicID := range autoClosed {
+			fmt.Fprintf(cmd.OutOrStdout(), "Auto-closed epic %s\n", epicID)
+		}
+		for _, row := range newlyReady {
+			fmt.Fprintf(cmd.OutOrStdout(), "Now ready: %s P%d %s\n", row.ID, row.Priority, strings.TrimSpace(row.Title))
+		}
 	}
 
 	return nil
@@ -75,7 +94,7 @@ func runClose(cmd *cobra.Command, args []string) error {
 
 func runReopen(cmd *cobra.Command, args []string) error {
 	if len(args) < 1 {
-		return fmt.Errorf("usage: tl reopen <id>")
+		return validationError("usage: tl reopen <id>")
 	}
 	id := args[0]
 
@@ -100,12 +119,9 @@ func runReopen(cmd *cobra.Command, args []string) error {
 			return nil, err
 		}
 
-		// Apply reopen state to in-memory issue for post-mutate capture
-		issue.Status = StatusOpen
-		issue.ClosedAt = nil
-		issue.CloseReason = ""
-		issue.Assignee = ""
-		issue.UpdatedAt = evt.Timestamp
+		if err := g.applyEvent(evt); err != nil {
+			return nil, err
+		}
 		updatedIssue = *issue
 
 		return []Event{evt}, nil
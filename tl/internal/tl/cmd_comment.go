@@ -0,0 +1,125 @@
+// ABOUTME: Comment commands — append to and print an issue's discussion thread.
+// ABOUTME: Implements `tl comment add <id> [text|-] [--reply-to N]` (text from stdin when omitted) and `tl comment list <id>`.
+
+package tl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var commentReplyTo int
+
+func init() {
+	commentAddCmd.Args = cobra.RangeArgs(1, 2)
+	commentAddCmd.Flags().IntVar(&commentReplyTo, "reply-to", 0, "Reply to the comment with this number")
+	commentAddCmd.RunE = runCommentAdd
+
+	commentListCmd.Args = cobra.ExactArgs(1)
+	commentListCmd.RunE = runCommentList
+}
+
+func runCommentAdd(cmd *cobra.Command, args []string) error {
+	id := args[0]
+	text := "-"
+	if len(args) == 2 {
+		text = args[1]
+	}
+	if text == "-" {
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		text = string(data)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return validationError("comment text is empty")
+	}
+	if commentReplyTo < 0 {
+		return validationError("--reply-to must be a comment number")
+	}
+
+	dir, err := tlDir(GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag})
+	if err != nil {
+		return err
+	}
+
+	var added Comment
+	err = mutate(dir, func(g *Graph) ([]Event, error) {
+		issue, ok := g.Tasks[id]
+		if !ok {
+			return nil, fmt.Errorf("issue %q: %w", id, ErrNotFound)
+		}
+		if commentReplyTo != 0 && findComment(issue, commentReplyTo) == nil {
+			return nil, fmt.Errorf("comment #%d on %s: %w", commentReplyTo, id, ErrNotFound)
+		}
+
+		evt, err := newEvent(EventComment, id, CommentEventData{CommentID: nextCommentID(issue), Text: text, ReplyTo: commentReplyTo})
+		if err != nil {
+			return nil, err
+		}
+		if err := g.applyEvent(evt); err != nil {
+			return nil, err
+		}
+		added = *issue.Comments[len(issue.Comments)-1]
+		return []Event{evt}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		data, err := json.Marshal(added)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+	if added.ReplyTo != 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "Added comment #%d to %s (reply to #%d)\n", added.ID, id, added.ReplyTo)
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Added comment #%d to %s\n", added.ID, id)
+	return nil
+}
+
+func runCommentList(cmd *cobra.Command, args []string) error {
+	id := args[0]
+	dir, err := tlDir(GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag})
+	if err != nil {
+		return err
+	}
+	graph, err := loadGraph(dir)
+	if err != nil {
+		return err
+	}
+	issue, ok := graph.Tasks[id]
+	if !ok {
+		return fmt.Errorf("issue %q: %w", id, ErrNotFound)
+	}
+
+	if jsonOutput {
+		comments := issue.Comments
+		if comments == nil {
+			comments = []*Comment{}
+		}
+		data, err := json.Marshal(comments)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+	if len(issue.Comments) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No comments on %s\n", id)
+		return nil
+	}
+	writeCommentThread(cmd.OutOrStdout(), issue, "")
+	return nil
+}
@@ -0,0 +1,54 @@
+// ABOUTME: Context command — prints a single prompt-ready document about one task.
+// ABOUTME: Implements `tl context <id> [--max-bytes N]` as Markdown, or JSON sections with --json.
+
+package tl
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var contextMaxBytes int
+
+func init() {
+	contextCmd.Args = cobra.ExactArgs(1)
+	contextCmd.Flags().IntVar(&contextMaxBytes, "max-bytes", 0, "Trim the document to at most N bytes, least important sections first (0 = no limit)")
+	contextCmd.RunE = runContext
+}
+
+func runContext(cmd *cobra.Command, args []string) error {
+	if contextMaxBytes < 0 {
+		return validationError("--max-bytes must not be negative")
+	}
+	dir, err := tlDir(GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag})
+	if err != nil {
+		return err
+	}
+	events, err := readEvents(filepath.Join(dir, eventsFileName))
+	if err != nil {
+		return err
+	}
+	graph, err := replayEvents(events)
+	if err != nil {
+		return err
+	}
+	if err := attachRemotes(graph, dir); err != nil {
+		return err
+	}
+
+	bundle, err := buildContext(graph, events, filepath.Dir(dir), args[0])
+	if err != nil {
+		return err
+	}
+	render := renderContextMarkdown
+	if jsonOutput {
+		render = renderContextJSON
+	}
+	out, err := fitContext(bundle, contextMaxBytes, render)
+	if err != nil {
+		return err
+	}
+	_, err = cmd.OutOrStdout().Write(out)
+	return err
+}
@@ -15,6 +15,7 @@ var (
 	createType        string
 	createPriority    int
 	createDescription string
+	createEstimate    int
 )
 
 func init() {
@@ -22,6 +23,7 @@ func init() {
 	createCmd.Flags().StringVar(&createType, "type", "task", "Issue type (task, bug, feature, chore, epic, decision)")
 	createCmd.Flags().IntVar(&createPriority, "priority", 2, "Priority (0=critical, 1=high, 2=medium, 3=low)")
 	createCmd.Flags().StringVar(&createDescription, "description", "", "Task description")
+	createCmd.Flags().IntVar(&createEstimate, "estimate", 0, "Estimated effort in minutes (0 = unset)")
 
 	createCmd.RunE = runCreate
 }
@@ -33,7 +35,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		title = args[0]
 	}
 	if title == "" {
-		return fmt.Errorf("title is required (use --title or pass as first argument)")
+		return validationError("title is required (use --title or pass as first argument)")
 	}
 
 	opts := GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag}
@@ -52,6 +54,13 @@ func runCreate(cmd *cobra.Command, args []string) error {
 			Priority:    createPriority,
 			IssueType:   createType,
 		}
+		if createEstimate > 0 {
+			raw, err := json.Marshal(createEstimate)
+			if err != nil {
+				return nil, err
+			}
+			data.Metadata = map[string]json.RawMessage{estimateMetadataKey: raw}
+		}
 		evt, err := newEvent(EventCreate, id, data)
 		if err != nil {
 			return nil, err
@@ -65,6 +74,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 			IssueType:   IssueType(data.IssueType),
 			CreatedAt:   evt.Timestamp,
 			UpdatedAt:   evt.Timestamp,
+			Metadata:    data.Metadata,
 		}
 		return []Event{evt}, nil
 	})
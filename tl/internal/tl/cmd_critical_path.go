@@ -0,0 +1,71 @@
+// ABOUTME: Critical-path command — reports the longest blocking chain and per-task slack.
+// ABOUTME: Implements `tl critical-path [<epic-id>]` in text or JSON, flagging ready critical tasks.
+
+package tl
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	criticalPathCmd.Args = cobra.MaximumNArgs(1)
+	criticalPathCmd.RunE = runCriticalPath
+}
+
+func runCriticalPath(cmd *cobra.Command, args []string) error {
+	scopeID := ""
+	if len(args) > 0 {
+		scopeID = args[0]
+	}
+
+	dir, err := tlDir(GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag})
+	if err != nil {
+		return err
+	}
+
+	graph, err := loadGraph(dir)
+	if err != nil {
+		return err
+	}
+
+	ready := collectReadyIssues(graph, computeBlockedSet(graph), time.Now())
+	result, err := computeCriticalPath(graph, scopeID, ready)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	w := cmd.OutOrStdout()
+	fmt.Fprintf(w, "Critical path (length %d): %s\n", result.Length, strings.Join(result.Path, " -> "))
+	fmt.Fprintf(w, "Ready on critical path: %s\n", strings.Join(result.ReadyCritical, " "))
+	for _, task := range result.Tasks {
+		marker := " "
+		if task.Critical {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s %s [%s] est=%d start=%d finish=%d slack=%d %s\n",
+			marker,
+			task.ID,
+			string(task.Status),
+			task.Estimate,
+			task.EarliestStart,
+			task.EarliestFinish,
+			task.Slack,
+			strings.TrimSpace(task.Title),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// ABOUTME: Tests `tl critical-path` command output in text and JSON forms.
+// ABOUTME: Uses replayed event logs so estimates and blocking edges come from real events.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCriticalPathTextAndJSON(t *testing.T) {
+	ts := time.Date(2026, 2, 2, 9, 0, 0, 0, time.UTC)
+	estimate, err := json.Marshal(UpdateEventData{Fields: map[string]json.RawMessage{estimateMetadataKey: json.RawMessage(`4`)}})
+	require.NoError(t, err)
+	dir := seedCommandRepoWithEvents(
+		t,
+		createIssueEvent(t, "tl-first", "First", StatusOpen, 1, ts),
+		createIssueEvent(t, "tl-second", "Second", StatusOpen, 1, ts.Add(time.Minute)),
+		createIssueEvent(t, "tl-side", "Side", StatusOpen, 0, ts.Add(2*time.Minute)),
+		depAddEvent(t, "tl-second", "tl-first", DepBlocks, ts.Add(3*time.Minute)),
+		Event{Type: EventUpdate, ID: "tl-second", Timestamp: ts.Add(4 * time.Minute), Actor: "test", Data: estimate},
+	)
+
+	setCommandGlobals(t, dir, false)
+	cmd := newTestCommand()
+	require.NoError(t, runCriticalPath(cmd, nil))
+
+	text := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, text, "Critical path (length 5): tl-first -> tl-second\n")
+	assert.Contains(t, text, "Ready on critical path: tl-first\n")
+	assert.Contains(t, text, "* tl-second [open] est=4 start=1 finish=5 slack=0 Second")
+	assert.Contains(t, text, "  tl-side [open] est=1 start=0 finish=1 slack=4 Side")
+
+	setCommandGlobals(t, dir, true)
+	cmd = newTestCommand()
+	require.NoError(t, runCriticalPath(cmd, nil))
+
+	var result criticalPathResult
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(cmd.OutOrStdout().(*bytes.Buffer).Bytes()), &result))
+	assert.Equal(t, 5, result.Length)
+	assert.Equal(t, []string{"tl-first", "tl-second"}, result.Path)
+	assert.Equal(t, []string{"tl-first"}, result.ReadyCritical)
+	require.Len(t, result.Tasks, 3)
+}
@@ -0,0 +1,112 @@
+// ABOUTME: Defer and undefer commands — park a task until a wake-up time, or bring it back early.
+// ABOUTME: Implements `tl defer <id> --until <time|duration>` and `tl undefer <id>`; deferred tasks wake on their own.
+
+package tl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var deferUntil string
+
+func init() {
+	deferCmd.Args = cobra.ExactArgs(1)
+	deferCmd.Flags().StringVar(&deferUntil, "until", "", "Wake-up time: timestamp, date, duration (3d, 4h) or tomorrow/next-monday")
+	deferCmd.RunE = runDefer
+
+	undeferCmd.Args = cobra.ExactArgs(1)
+	undeferCmd.RunE = runUndefer
+}
+
+func runDefer(cmd *cobra.Command, args []string) error {
+	id := args[0]
+	if deferUntil == "" {
+		return validationError("--until is required")
+	}
+	until, err := parseDeferUntil(deferUntil, time.Now())
+	if err != nil {
+		return err
+	}
+	if !until.After(time.Now()) {
+		return validationError("defer time %s is not in the future", until.Format(time.RFC3339))
+	}
+
+	opts := GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag}
+	dir, err := tlDir(opts)
+	if err != nil {
+		return err
+	}
+
+	var deferred Issue
+	err = mutate(dir, func(g *Graph) ([]Event, error) {
+		issue, ok := g.Tasks[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		if err := validateTransition(issue.Status, StatusDeferred); err != nil {
+			return nil, err
+		}
+
+		evt, err := newEvent(EventDefer, id, DeferEventData{Until: until.UTC()})
+		if err != nil {
+			return nil, err
+		}
+		if err := g.applyEvent(evt); err != nil {
+			return nil, err
+		}
+		deferred = *issue
+		return []Event{evt}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		return printIssueJSON(cmd, &deferred)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Deferred %s until %s\n", deferred.ID, deferred.DeferUntil.Local().Format(time.RFC3339))
+	return nil
+}
+
+func runUndefer(cmd *cobra.Command, args []string) error {
+	id := args[0]
+
+	opts := GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag}
+	dir, err := tlDir(opts)
+	if err != nil {
+		return err
+	}
+
+	var undeferred Issue
+	err = mutate(dir, func(g *Graph) ([]Event, error) {
+		issue, ok := g.Tasks[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		if issue.Status != StatusDeferred {
+			return nil, conflictError("task %s is not deferred (status: %s)", id, issue.Status)
+		}
+
+		evt, err := newEvent(EventUndefer, id, UndeferEventData{})
+		if err != nil {
+			return nil, err
+		}
+		if err := g.applyEvent(evt); err != nil {
+			return nil, err
+		}
+		undeferred = *issue
+		return []Event{evt}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		return printIssueJSON(cmd, &undeferred)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Undeferred %s\n", undeferred.ID)
+	return nil
+}
@@ -1,51 +1,98 @@
 // ABOUTME: Dependency management commands for adding and removing task dependencies.
-// ABOUTME: Implements `tl dep add` and `tl dep remove` with cycle detection.
+// ABOUTME: Implements `tl dep add` (validated, idempotent, cycle-checked, with edge conditions), `tl dep remove` and `tl dep cycles`.
 
 package tl
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
-var depType string
+var (
+	depType           string
+	depIfClosedReason string
+	depIfLabel        string
+	depGate           string
+	depAllowDangling  bool
+)
 
 func init() {
 	depAddCmd.Args = cobra.ExactArgs(2)
 	depRemoveCmd.Args = cobra.ExactArgs(2)
+	depCyclesCmd.Args = cobra.NoArgs
 	depAddCmd.Flags().StringVar(&depType, "type", string(DepBlocks), "Dependency type")
+	depAddCmd.Flags().StringVar(&depIfClosedReason, "if-closed-reason", "", "conditional-blocks: block only while the target is closed with this reason")
+	depAddCmd.Flags().StringVar(&depIfLabel, "if-label", "", "conditional-blocks: block only while the target has this label")
+	depAddCmd.Flags().StringVar(&depGate, "gate", "", "waits-for: fan-in gate over the target's children (all-children, any-children)")
+	depAddCmd.Flags().BoolVar(&depAllowDangling, "allow-dangling", false, "Allow a target that does not exist (locally or in a configured remote)")
 
 	depAddCmd.RunE = runDepAdd
 	depRemoveCmd.RunE = runDepRemove
+	depCyclesCmd.RunE = runDepCycles
 }
 
 func runDepAdd(cmd *cobra.Command, args []string) error {
 	issueID := args[0]
 	dependsOnID := args[1]
 
+	if !DependencyType(depType).IsValid() {
+		return validationError("unknown dependency type %q (want one of: %s)", depType, strings.Join(knownDependencyTypes(), ", "))
+	}
+	cond := DepCondition{CloseReason: depIfClosedReason, Label: depIfLabel, Gate: depGate}
+	if err := validateDepCondition(DependencyType(depType), cond); err != nil {
+		return err
+	}
+	var metadata json.RawMessage
+	if !cond.IsZero() {
+		raw, err := json.Marshal(cond)
+		if err != nil {
+			return err
+		}
+		metadata = raw
+	}
+
 	opts := GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag}
 	dir, err := tlDir(opts)
 	if err != nil {
 		return err
 	}
 
+	var previous *Dependency
 	err = mutate(dir, func(graph *Graph) ([]Event, error) {
 		if issueID == dependsOnID {
-			return nil, errors.New("cannot depend on self")
+			return nil, validationError("cannot depend on self")
 		}
-		if _, ok := graph.Tasks[issueID]; !ok {
+		issue, ok := graph.Tasks[issueID]
+		if !ok {
 			return nil, fmt.Errorf("issue %q: %w", issueID, ErrNotFound)
 		}
-		if hasCycle(graph, issueID, dependsOnID) {
-			return nil, ErrCycle
+		if err := checkDepTarget(cmd, dir, graph, dependsOnID, depAllowDangling); err != nil {
+			return nil, err
+		}
+
+		existing := findDependency(issue, dependsOnID)
+		if existing != nil {
+			prev := *existing
+			previous = &prev
+			if existing.Type == DependencyType(depType) && bytes.Equal(existing.Metadata, metadata) {
+				return nil, nil
+			}
+		}
+		if needsCycleCheck(existing, DependencyType(depType)) {
+			if path := cyclePath(graph, issueID, dependsOnID); path != nil {
+				return nil, &CycleError{Path: path}
+			}
 		}
 
 		evt, err := newEvent(EventDepAdd, issueID, DepAddEventData{
 			DependsOnID: dependsOnID,
 			DepType:     depType,
+			Metadata:    metadata,
 		})
 		if err != nil {
 			return nil, err
@@ -69,10 +116,76 @@ func runDepAdd(cmd *cobra.Command, args []string) error {
 		return printIssueJSON(cmd, issue)
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Added dependency: %s depends on %s\n", issueID, dependsOnID)
+	switch {
+	case previous == nil:
+		fmt.Fprintf(cmd.OutOrStdout(), "Added dependency: %s depends on %s\n", issueID, dependsOnID)
+	case previous.Type != DependencyType(depType):
+		fmt.Fprintf(cmd.OutOrStdout(), "Changed dependency: %s depends on %s (%s → %s)\n", issueID, dependsOnID, previous.Type, depType)
+	case !bytes.Equal(previous.Metadata, metadata):
+		fmt.Fprintf(cmd.OutOrStdout(), "Updated dependency conditions: %s depends on %s\n", issueID, dependsOnID)
+	default:
+		fmt.Fprintf(cmd.OutOrStdout(), "Dependency already exists: %s depends on %s (%s)\n", issueID, dependsOnID, depType)
+	}
+	return nil
+}
+
+// checkDepTarget rejects a dependency target that exists neither locally nor
+// in a configured remote, unless allowDangling is set.
+func checkDepTarget(cmd *cobra.Command, dir string, graph *Graph, id string, allowDangling bool) error {
+	if _, ok := graph.Tasks[id]; ok || allowDangling {
+		return nil
+	}
+	if _, _, qualified := splitQualifiedID(id); !qualified {
+		return fmt.Errorf("dependency target %q: %w (use --allow-dangling to add it anyway)", id, ErrNotFound)
+	}
+	return checkRemoteTarget(cmd, dir, id)
+}
+
+// checkRemoteTarget validates a qualified target. The alias must be
+// configured and, when the remote repo is readable, the issue must exist
+// there; an unreadable repo only warns since its status is merely unknown.
+func checkRemoteTarget(cmd *cobra.Command, dir, id string) error {
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return err
+	}
+	alias, _, _ := splitQualifiedID(id)
+	if _, ok := cfg.Remotes[alias]; !ok {
+		return validationError("dependency target %q: remote %q is not configured in %s", id, alias, configFileName)
+	}
+	ref := newRemoteResolver(dir, cfg).resolve(id)
+	switch {
+	case ref.Issue != nil:
+		return nil
+	case errors.Is(ref.Err, ErrNotFound):
+		return fmt.Errorf("dependency target %q: %w", id, ref.Err)
+	default:
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s status unknown: %v\n", id, ref.Err)
+		return nil
+	}
+}
+
+// findDependency returns the issue's edge to dependsOnID, if any.
+func findDependency(issue *Issue, dependsOnID string) *Dependency {
+	for _, dep := range issue.Dependencies {
+		if dep != nil && dep.DependsOnID == dependsOnID {
+			return dep
+		}
+	}
 	return nil
 }
 
+func knownDependencyTypes() []string {
+	return []string{
+		string(DepBlocks),
+		string(DepParentChild),
+		string(DepConditionalBlocks),
+		string(DepWaitsFor),
+		string(DepRelated),
+		string(DepDiscoveredFrom),
+	}
+}
+
 func runDepRemove(cmd *cobra.Command, args []string) error {
 	issueID := args[0]
 	dependsOnID := args[1]
@@ -126,6 +239,40 @@ func runDepRemove(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runDepCycles(cmd *cobra.Command, args []string) error {
+	dir, err := tlDir(GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag})
+	if err != nil {
+		return err
+	}
+
+	graph, err := loadGraph(dir)
+	if err != nil {
+		return err
+	}
+
+	cycles := findDependencyCycles(graph)
+	if jsonOutput {
+		if cycles == nil {
+			cycles = []dependencyCycle{}
+		}
+		data, err := json.Marshal(cycles)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	if len(cycles) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No dependency cycles found")
+		return nil
+	}
+	for i, cycle := range cycles {
+		fmt.Fprintf(cmd.OutOrStdout(), "Cycle %d (%d tasks): %s\n", i+1, len(cycle.Members), cycle)
+	}
+	return nil
+}
+
 func printIssueJSON(cmd *cobra.Command, issue *Issue) error {
 	data, err := json.Marshal(issue)
 	if err != nil {
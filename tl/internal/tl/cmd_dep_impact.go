@@ -0,0 +1,61 @@
+// ABOUTME: Dep impact command — previews what closing a task would unblock.
+// ABOUTME: Implements `tl dep impact <id>` listing newly ready, still blocked, and downstream counts.
+
+package tl
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	depImpactCmd.Args = cobra.ExactArgs(1)
+	depImpactCmd.RunE = runDepImpact
+}
+
+func runDepImpact(cmd *cobra.Command, args []string) error {
+	id := args[0]
+
+	dir, err := tlDir(GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag})
+	if err != nil {
+		return err
+	}
+
+	graph, err := loadGraph(dir)
+	if err != nil {
+		return err
+	}
+	if _, ok := graph.Tasks[id]; !ok {
+		return fmt.Errorf("issue %q: %w", id, ErrNotFound)
+	}
+
+	report, err := computeCloseImpact(graph, id, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		data, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	w := cmd.OutOrStdout()
+	fmt.Fprintf(w, "Closing %s affects %d downstream tasks\n", id, report.Downstream)
+	fmt.Fprintf(w, "Newly ready (%d):\n", len(report.NewlyReady))
+	for _, row := range report.NewlyReady {
+		fmt.Fprintf(w, "  %s P%d %s\n", row.ID, row.Priority, strings.TrimSpace(row.Title))
+	}
+	fmt.Fprintf(w, "Still blocked (%d):\n", len(report.StillBlocked))
+	for _, row := range report.StillBlocked {
+		fmt.Fprintf(w, "  %s [%s] %s (blocked by: %s)\n", row.ID, string(row.Status), strings.TrimSpace(row.Title), strings.Join(row.Blockers, " "))
+	}
+	return nil
+}
@@ -1,13 +1,15 @@
 // ABOUTME: Tests dependency CLI command handlers for add/remove edge mutations.
-// ABOUTME: Covers cycle checks, self-dependency rejection, and permissive dependency types.
+// ABOUTME: Covers cycle paths, self-dependency rejection, type/target validation, idempotent re-adds and dep tree.
 
 package tl
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -29,20 +31,121 @@ func TestDepAddRejectsCycle(t *testing.T) {
 	assert.Empty(t, graph.Deps["tl-b"])
 }
 
-func TestDepAddAcceptsUnknownType(t *testing.T) {
+func TestDepAddCycleCheckFollowsReadyEdgesOnly(t *testing.T) {
+	dir := setupDepRepo(t, "tl-a", "tl-b", "tl-c")
+	setDepCommandGlobals(t, dir)
+	require.NoError(t, runDepAdd(newDepCommand(t), []string{"tl-b", "tl-a"}))
+
+	for _, linkType := range []DependencyType{DepRelated, DepDiscoveredFrom} {
+		depType = string(linkType)
+		require.NoError(t, runDepAdd(newDepCommand(t), []string{"tl-a", "tl-b"}), "a %s link cannot block anything", linkType)
+	}
+	depType = string(DepBlocks)
+	assert.ErrorIs(t, runDepAdd(newDepCommand(t), []string{"tl-a", "tl-b"}), ErrCycle, "retyping the link to blocks closes the cycle")
+
+	depType = string(DepRelated)
+	require.NoError(t, runDepAdd(newDepCommand(t), []string{"tl-c", "tl-b"}))
+	depType = string(DepBlocks)
+	require.NoError(t, runDepAdd(newDepCommand(t), []string{"tl-a", "tl-c"}), "the path back through a related link is not a cycle")
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.Empty(t, findDependencyCycles(graph), "dep add and dep cycles agree")
+}
+
+func TestDepAddRejectsUnknownType(t *testing.T) {
 	dir := setupDepRepo(t, "tl-a", "tl-b")
 	setDepCommandGlobals(t, dir)
 	depType = "soft-link"
 
 	err := runDepAdd(newDepCommand(t), []string{"tl-a", "tl-b"})
-	require.NoError(t, err)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown dependency type "soft-link"`)
 
 	graph, loadErr := loadGraph(dir)
 	require.NoError(t, loadErr)
+	assert.Empty(t, graph.Tasks["tl-a"].Dependencies)
+}
 
-	require.Contains(t, graph.Tasks, "tl-a")
+func TestDepAddRejectsMissingTarget(t *testing.T) {
+	dir := setupDepRepo(t, "tl-a")
+	setDepCommandGlobals(t, dir)
+
+	err := runDepAdd(newDepCommand(t), []string{"tl-a", "tl-ghost"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	depAllowDangling = true
+	require.NoError(t, runDepAdd(newDepCommand(t), []string{"tl-a", "api:tl-ghost"}))
+
+	graph, loadErr := loadGraph(dir)
+	require.NoError(t, loadErr)
+	assert.Equal(t, []string{"api:tl-ghost"}, graph.Deps["tl-a"])
+}
+
+func TestDepAddDuplicateIsIdempotent(t *testing.T) {
+	dir := setupDepRepo(t, "tl-a", "tl-b")
+	setDepCommandGlobals(t, dir)
+
+	require.NoError(t, runDepAdd(newDepCommand(t), []string{"tl-a", "tl-b"}))
+	before, err := readEvents(filepath.Join(dir, eventsFileName))
+	require.NoError(t, err)
+
+	cmd := newDepCommand(t)
+	require.NoError(t, runDepAdd(cmd, []string{"tl-a", "tl-b"}))
+	assert.Equal(t, "Dependency already exists: tl-a depends on tl-b (blocks)\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	after, err := readEvents(filepath.Join(dir, eventsFileName))
+	require.NoError(t, err)
+	assert.Len(t, after, len(before), "duplicate add appends no event")
+}
+
+func TestDepAddChangesType(t *testing.T) {
+	dir := setupDepRepo(t, "tl-a", "tl-b")
+	setDepCommandGlobals(t, dir)
+
+	require.NoError(t, runDepAdd(newDepCommand(t), []string{"tl-a", "tl-b"}))
+	depType = string(DepRelated)
+	cmd := newDepCommand(t)
+	require.NoError(t, runDepAdd(cmd, []string{"tl-a", "tl-b"}))
+	assert.Equal(t, "Changed dependency: tl-a depends on tl-b (blocks → related)\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
 	require.Len(t, graph.Tasks["tl-a"].Dependencies, 1)
-	assert.Equal(t, DependencyType("soft-link"), graph.Tasks["tl-a"].Dependencies[0].Type)
+	assert.Equal(t, DepRelated, graph.Tasks["tl-a"].Dependencies[0].Type)
+	assert.Equal(t, []string{"tl-b"}, graph.Deps["tl-a"])
+	assert.Equal(t, []string{"tl-a"}, graph.RDeps["tl-b"])
+	assert.False(t, computeBlockedSet(graph)["tl-a"])
+}
+
+func TestDepAddCycleReportsPath(t *testing.T) {
+	dir := setupDepRepo(t, "tl-a", "tl-b", "tl-c")
+	setDepCommandGlobals(t, dir)
+
+	require.NoError(t, runDepAdd(newDepCommand(t), []string{"tl-a", "tl-b"}))
+	require.NoError(t, runDepAdd(newDepCommand(t), []string{"tl-b", "tl-c"}))
+
+	err := runDepAdd(newDepCommand(t), []string{"tl-c", "tl-a"})
+	require.Error(t, err)
+	assert.EqualError(t, err, "dependency would create a cycle: tl-c -> tl-a -> tl-b -> tl-c")
+
+	require.ErrorIs(t, err, ErrCycle)
+	assert.Equal(t, exitConflict, exitCode(err))
+
+	var buf bytes.Buffer
+	writeError(&buf, err, true)
+	var payload struct {
+		Error struct {
+			Code    string `json:"code"`
+			Details struct {
+				Path []string `json:"path"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &payload))
+	assert.Equal(t, codeCycle, payload.Error.Code)
+	assert.Equal(t, []string{"tl-c", "tl-a", "tl-b", "tl-c"}, payload.Error.Details.Path)
 }
 
 func TestDepAddRejectsSelfDependency(t *testing.T) {
@@ -93,15 +196,27 @@ func setDepCommandGlobals(t *testing.T, dir string) {
 	prevJSON := jsonOutput
 	prevDir := tlDirFlag
 	prevType := depType
+	prevIfClosedReason := depIfClosedReason
+	prevIfLabel := depIfLabel
+	prevGate := depGate
+	prevAllowDangling := depAllowDangling
 	t.Cleanup(func() {
 		jsonOutput = prevJSON
 		tlDirFlag = prevDir
 		depType = prevType
+		depIfClosedReason = prevIfClosedReason
+		depIfLabel = prevIfLabel
+		depGate = prevGate
+		depAllowDangling = prevAllowDangling
 	})
 
 	jsonOutput = false
 	tlDirFlag = dir
 	depType = string(DepBlocks)
+	depIfClosedReason = ""
+	depIfLabel = ""
+	depGate = ""
+	depAllowDangling = false
 }
 
 func setupDepRepo(t *testing.T, issueIDs ...string) string {
@@ -138,3 +253,80 @@ func TestDepRemoveMissingDependencyReturnsNotFound(t *testing.T) {
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, ErrNotFound))
 }
+
+func TestDepAddStoresCondition(t *testing.T) {
+	dir := setupDepRepo(t, "tl-a", "tl-b")
+	setDepCommandGlobals(t, dir)
+	depType = string(DepConditionalBlocks)
+	depIfClosedReason = "failed"
+
+	require.NoError(t, runDepAdd(newDepCommand(t), []string{"tl-b", "tl-a"}))
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	require.Len(t, graph.Tasks["tl-b"].Dependencies, 1)
+	cond := parseDepCondition(graph.Tasks["tl-b"].Dependencies[0].Metadata)
+	assert.Equal(t, DepCondition{CloseReason: "failed"}, cond)
+}
+
+func TestDepAddRejectsConditionOnWrongType(t *testing.T) {
+	dir := setupDepRepo(t, "tl-a", "tl-b")
+	setDepCommandGlobals(t, dir)
+	depGate = GateAllChildren
+
+	err := runDepAdd(newDepCommand(t), []string{"tl-b", "tl-a"})
+	require.Error(t, err)
+
+	graph, loadErr := loadGraph(dir)
+	require.NoError(t, loadErr)
+	assert.Empty(t, graph.Tasks["tl-b"].Dependencies)
+}
+
+func TestDepCyclesNoneAndJSON(t *testing.T) {
+	dir := setupDepRepo(t, "tl-a", "tl-b")
+	setDepCommandGlobals(t, dir)
+
+	cmd := newDepCommand(t)
+	require.NoError(t, runDepCycles(cmd, nil))
+	assert.Equal(t, "No dependency cycles found\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	require.NoError(t, appendEventsToFile(filepath.Join(dir, eventsFileName), []Event{
+		depAddEvent(t, "tl-a", "tl-b", DepBlocks, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+		depAddEvent(t, "tl-b", "tl-a", DepBlocks, time.Date(2026, 1, 1, 0, 1, 0, 0, time.UTC)),
+	}))
+
+	cmd = newDepCommand(t)
+	require.NoError(t, runDepCycles(cmd, nil))
+	assert.Equal(t, "Cycle 1 (2 tasks): tl-a -[blocks]-> tl-b -[blocks]-> tl-a\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	jsonOutput = true
+	cmd = newDepCommand(t)
+	require.NoError(t, runDepCycles(cmd, nil))
+	var cycles []dependencyCycle
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(cmd.OutOrStdout().(*bytes.Buffer).Bytes()), &cycles))
+	require.Len(t, cycles, 1)
+	assert.Equal(t, []string{"tl-a", "tl-b"}, cycles[0].Members)
+}
+
+func TestDepTreeListsSharedDependenciesOnce(t *testing.T) {
+	dir := setupDepRepo(t, "tl-a", "tl-b", "tl-c", "tl-d", "tl-e")
+	setDepCommandGlobals(t, dir)
+	ts := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, appendEventsToFile(filepath.Join(dir, eventsFileName), []Event{
+		depAddEvent(t, "tl-a", "tl-b", DepBlocks, ts),
+		depAddEvent(t, "tl-a", "tl-c", DepBlocks, ts),
+		depAddEvent(t, "tl-b", "tl-d", DepBlocks, ts),
+		depAddEvent(t, "tl-c", "tl-d", DepBlocks, ts),
+		depAddEvent(t, "tl-d", "tl-e", DepBlocks, ts),
+	}))
+
+	cmd := newDepCommand(t)
+	require.NoError(t, runDepTree(cmd, []string{"tl-a"}))
+	assert.Equal(t, "tl-a [open] tl-a\n"+
+		"  blocks tl-b [open] tl-b\n"+
+		"    blocks tl-d [open] tl-d\n"+
+		"      blocks tl-e [open] tl-e\n"+
+		"  blocks tl-c [open] tl-c\n"+
+		"    blocks tl-d [open] tl-d (see above)\n",
+		cmd.OutOrStdout().(*bytes.Buffer).String())
+}
@@ -0,0 +1,143 @@
+// ABOUTME: Dep tree command — shows everything a task depends on, recursively.
+// ABOUTME: Implements `tl dep tree <id>`, resolving cross-repo targets and flagging unknown ones.
+
+package tl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// depTreeNode is one issue in a dependency tree. Type is the edge that led
+// here from the parent node; Unknown explains a target whose status could not
+// be resolved. Seen marks an issue whose dependencies were already listed
+// earlier in the tree.
+type depTreeNode struct {
+	ID       string         `json:"id"`
+	Title    string         `json:"title,omitempty"`
+	Status   Status         `json:"status,omitempty"`
+	Type     DependencyType `json:"type,omitempty"`
+	Remote   bool           `json:"remote,omitempty"`
+	Unknown  string         `json:"unknown,omitempty"`
+	Cycle    bool           `json:"cycle,omitempty"`
+	Seen     bool           `json:"seen,omitempty"`
+	Children []*depTreeNode `json:"children,omitempty"`
+}
+
+func init() {
+	depTreeCmd.Args = cobra.ExactArgs(1)
+	depTreeCmd.RunE = runDepTree
+}
+
+func runDepTree(cmd *cobra.Command, args []string) error {
+	id := args[0]
+
+	dir, err := tlDir(GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag})
+	if err != nil {
+		return err
+	}
+
+	graph, err := loadGraph(dir)
+	if err != nil {
+		return err
+	}
+	if _, ok := graph.Tasks[id]; !ok {
+		return fmt.Errorf("issue %q: %w", id, ErrNotFound)
+	}
+
+	root := buildDepTree(graph, id, "", map[string]bool{}, map[string]bool{})
+
+	if jsonOutput {
+		data, err := json.Marshal(root)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	printDepTree(cmd.OutOrStdout(), root, 0)
+	return nil
+}
+
+// buildDepTree expands id's dependencies depth first. onPath guards against
+// cycles; expanded keeps shared dependencies from being listed again on every
+// path that reaches them, which would grow the tree exponentially.
+func buildDepTree(graph *Graph, id string, depType DependencyType, onPath, expanded map[string]bool) *depTreeNode {
+	node := &depTreeNode{ID: id, Type: depType}
+
+	issue, ok := graph.Tasks[id]
+	if !ok {
+		ref, remote := graph.remote[id]
+		switch {
+		case !remote:
+			node.Unknown = "not found"
+		case ref.Issue == nil:
+			node.Remote = true
+			node.Unknown = ref.Err.Error()
+		default:
+			node.Remote = true
+			node.Title = ref.Issue.Title
+			node.Status = ref.Issue.Status
+		}
+		return node
+	}
+
+	node.Title = issue.Title
+	node.Status = issue.Status
+	if onPath[id] {
+		node.Cycle = true
+		return node
+	}
+	if expanded[id] {
+		node.Seen = len(issue.Dependencies) > 0
+		return node
+	}
+
+	onPath[id] = true
+	expanded[id] = true
+	for _, dep := range issue.Dependencies {
+		if dep == nil {
+			continue
+		}
+		node.Children = append(node.Children, buildDepTree(graph, dep.DependsOnID, dep.Type, onPath, expanded))
+	}
+	delete(onPath, id)
+	return node
+}
+
+func printDepTree(w io.Writer, node *depTreeNode, depth int) {
+	indent := strings.Repeat("  ", depth)
+	edge := ""
+	if node.Type != "" {
+		edge = string(node.Type) + " "
+	}
+
+	status := string(node.Status)
+	if node.Unknown != "" {
+		status = "unknown"
+	}
+	line := fmt.Sprintf("%s%s%s [%s]", indent, edge, node.ID, status)
+	if title := strings.TrimSpace(node.Title); title != "" {
+		line += " " + title
+	}
+	switch {
+	case node.Unknown != "":
+		line += " (" + node.Unknown + ")"
+	case node.Cycle:
+		line += " (cycle)"
+	case node.Seen:
+		line += " (see above)"
+	case node.Remote:
+		line += " (remote)"
+	}
+	fmt.Fprintln(w, line)
+
+	for _, child := range node.Children {
+		printDepTree(w, child, depth+1)
+	}
+}
@@ -0,0 +1,166 @@
+// ABOUTME: Edit command — opens a task in $EDITOR as Markdown with YAML front matter and applies the changes.
+// ABOUTME: Implements `tl edit <id>`; appends only the update and dep events needed and rejects edits that raced a concurrent change.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var editAllowDangling bool
+
+func init() {
+	editCmd.Args = cobra.ExactArgs(1)
+	editCmd.Flags().BoolVar(&editAllowDangling, "allow-dangling", false, "Allow new dependency targets that do not exist (locally or in a configured remote)")
+	editCmd.RunE = runEdit
+}
+
+// runEditor opens path in the user's editor and waits for it to exit.
+// Tests replace it to edit the file programmatically.
+var runEditor = func(cmd *cobra.Command, path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	argv := append(strings.Fields(editor), path)
+	proc := exec.Command(argv[0], argv[1:]...)
+	proc.Stdin, proc.Stdout, proc.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := proc.Run(); err != nil {
+		return fmt.Errorf("editor %q: %w", editor, err)
+	}
+	return nil
+}
+
+func runEdit(cmd *cobra.Command, args []string) error {
+	id := args[0]
+	opts := GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag}
+	dir, err := tlDir(opts)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return err
+	}
+
+	graph, err := loadGraph(dir)
+	if err != nil {
+		return err
+	}
+	issue, ok := graph.Tasks[id]
+	if !ok {
+		return fmt.Errorf("issue %q: %w", id, ErrNotFound)
+	}
+	before, err := json.Marshal(issue)
+	if err != nil {
+		return err
+	}
+	original, err := newEditDocument(issue).render()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp("", "tl-edit-"+id+"-*.md")
+	if err != nil {
+		return err
+	}
+	path := file.Name()
+	_, err = file.Write(original)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+	// The edited file is kept when the edit is rejected, so the work in it
+	// is not lost.
+	keep := false
+	defer func() {
+		if !keep {
+			os.Remove(path)
+		}
+	}()
+
+	if err := runEditor(cmd, path); err != nil {
+		return err
+	}
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(edited, original) {
+		fmt.Fprintf(cmd.OutOrStdout(), "No changes to %s\n", id)
+		return nil
+	}
+	doc, err := parseEditDocument(edited)
+	if err != nil {
+		keep = true
+		return fmt.Errorf("%w (edit kept in %s)", err, path)
+	}
+
+	var change editChange
+	var result Issue
+	err = mutate(dir, func(g *Graph) ([]Event, error) {
+		current, ok := g.Tasks[id]
+		if !ok {
+			return nil, fmt.Errorf("issue %q: %w", id, ErrNotFound)
+		}
+		now, err := json.Marshal(current)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(now, before) {
+			return nil, conflictError("%s changed while it was being edited", id)
+		}
+		checkTarget := func(target string) error {
+			return checkDepTarget(cmd, dir, g, target, editAllowDangling)
+		}
+		change, err = diffEdit(g, current, doc, cfg.WIP, time.Now(), checkTarget)
+		if err != nil {
+			return nil, err
+		}
+		for _, evt := range change.Events {
+			if err := g.applyEvent(evt); err != nil {
+				return nil, err
+			}
+		}
+		if change.Close {
+			closed, err := closeIssue(g, cfg, current, "", false)
+			if err != nil {
+				return nil, err
+			}
+			change.Events = append(change.Events, closed.Events...)
+			for _, epicID := range closed.AutoClosed {
+				change.Summary = append(change.Summary, "auto-closed epic "+epicID)
+			}
+		}
+		result = *current
+		return change.Events, nil
+	})
+	if err != nil {
+		keep = true
+		return fmt.Errorf("%w (edit kept in %s)", err, path)
+	}
+
+	if opts.JSON {
+		return printIssueJSON(cmd, &result)
+	}
+	if len(change.Events) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No changes to %s\n", id)
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Edited %s: %s\n", id, strings.Join(change.Summary, ", "))
+	return nil
+}
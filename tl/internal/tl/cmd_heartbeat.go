@@ -0,0 +1,86 @@
+// ABOUTME: Heartbeat command — renews the lease on a claimed task.
+// ABOUTME: Implements `tl heartbeat <id> [--lease d]`; only the claiming agent may renew an unexpired lease.
+
+package tl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	heartbeatAgent string
+	heartbeatLease string
+)
+
+func init() {
+	heartbeatCmd.Args = cobra.ExactArgs(1)
+	heartbeatCmd.Flags().StringVar(&heartbeatAgent, "agent", resolveActor(), "Agent holding the claim")
+	heartbeatCmd.Flags().StringVar(&heartbeatLease, "lease", "", "New lease duration (default: the current lease's duration)")
+	heartbeatCmd.RunE = runHeartbeat
+}
+
+func runHeartbeat(cmd *cobra.Command, args []string) error {
+	id := args[0]
+	agent := heartbeatAgent
+	if agent == "" {
+		agent = resolveActor()
+	}
+	if heartbeatLease != "" {
+		if _, err := parseLeaseDuration(heartbeatLease); err != nil {
+			return err
+		}
+	}
+
+	opts := GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag}
+	dir, err := tlDir(opts)
+	if err != nil {
+		return err
+	}
+
+	var renewed Issue
+	err = mutate(dir, func(g *Graph) ([]Event, error) {
+		issue, ok := g.Tasks[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		if issue.Status != StatusInProgress {
+			return nil, conflictError("task %s is not in progress (status: %s)", id, issue.Status)
+		}
+		if issue.Assignee != agent {
+			return nil, conflictError("task %s is claimed by %s, not %s", id, issue.Assignee, agent)
+		}
+		if leaseExpired(issue, time.Now()) {
+			return nil, conflictError("lease on %s expired at %s; claim it again", id, issue.Lease.ExpiresAt.Format(time.RFC3339))
+		}
+
+		lease := heartbeatLease
+		if lease == "" {
+			if issue.Lease == nil {
+				return nil, validationError("task %s has no lease; pass --lease to start one", id)
+			}
+			lease = issue.Lease.Duration
+		}
+
+		evt, err := newEvent(EventHeartbeat, id, HeartbeatEventData{Agent: agent, Lease: lease})
+		if err != nil {
+			return nil, err
+		}
+		if err := g.applyEvent(evt); err != nil {
+			return nil, err
+		}
+		renewed = *issue
+		return []Event{evt}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		return printIssueJSON(cmd, &renewed)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Lease on %s renewed until %s\n", renewed.ID, renewed.Lease.ExpiresAt.Format(time.RFC3339))
+	return nil
+}
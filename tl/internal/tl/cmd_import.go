@@ -1,5 +1,5 @@
 // ABOUTME: Import command — reads beads JSONL and imports tasks with content-hash deduplication.
-// ABOUTME: Implements `tl import --from <path>` preserving beads IDs and unknown fields.
+// ABOUTME: Implements `tl import --from <path>` preserving beads IDs and unknown fields, warning on new cycles.
 
 package tl
 
@@ -12,16 +12,21 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var importFromPath string
+var (
+	importFromPath string
+	importStrict   bool
+)
 
 type importCounts struct {
-	Imported int `json:"imported"`
-	Updated  int `json:"updated"`
-	Skipped  int `json:"skipped"`
+	Imported int               `json:"imported"`
+	Updated  int               `json:"updated"`
+	Skipped  int               `json:"skipped"`
+	Cycles   []dependencyCycle `json:"cycles,omitempty"`
 }
 
 func init() {
 	importCmd.Flags().StringVar(&importFromPath, "from", ".beads/issues.jsonl", "Path to beads JSONL file")
+	importCmd.Flags().BoolVar(&importStrict, "strict", false, "Reject the import if it introduces a dependency cycle")
 	importCmd.RunE = runImport
 }
 
@@ -43,6 +48,10 @@ func runImport(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return nil, err
 		}
+		cyclesBefore := findDependencyCycles(graph)
+		// Import builds its view of the result in place rather than through
+		// applyEvent, so the tracked blocked set would go stale.
+		graph.blocked = nil
 
 		events := make([]Event, 0, len(issues))
 		for _, incoming := range issues {
@@ -55,7 +64,9 @@ func runImport(cmd *cobra.Command, args []string) error {
 				events = append(events, evt)
 				counts.Imported++
 
-				graph.Tasks[incoming.ID] = cloneIssue(incoming)
+				created := cloneIssue(incoming)
+				created.Comments = nil
+				graph.Tasks[incoming.ID] = created
 				continue
 			}
 
@@ -113,6 +124,7 @@ func runImport(cmd *cobra.Command, args []string) error {
 				evt, err := newEvent(EventDepAdd, issue.ID, DepAddEventData{
 					DependsOnID: dependsOnID,
 					DepType:     depType,
+					Metadata:    cloneRawMessage(dep.Metadata),
 				})
 				if err != nil {
 					return nil, err
@@ -131,17 +143,35 @@ func runImport(cmd *cobra.Command, args []string) error {
 						Type:        DependencyType(depType),
 						CreatedAt:   evt.Timestamp,
 						CreatedBy:   dep.CreatedBy,
+						Metadata:    cloneRawMessage(dep.Metadata),
 					})
 				}
 			}
 		}
 
+		for _, issue := range issues {
+			commentEvents, err := importComments(graph.Tasks[issue.ID], issue.Comments)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, commentEvents...)
+		}
+
+		counts.Cycles = introducedCycles(cyclesBefore, findDependencyCycles(graph))
+		if importStrict && len(counts.Cycles) > 0 {
+			return nil, fmt.Errorf("import rejected: %w: %s", ErrCycle, counts.Cycles[0])
+		}
+
 		return events, nil
 	})
 	if err != nil {
 		return err
 	}
 
+	for _, cycle := range counts.Cycles {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: import introduces dependency cycle: %s\n", cycle)
+	}
+
 	if jsonOutput {
 		payload, err := json.Marshal(counts)
 		if err != nil {
@@ -164,6 +194,9 @@ func buildCreateEvent(issue *Issue) (Event, error) {
 		IssueType:   string(issue.IssueType),
 		Labels:      issue.Labels,
 		Metadata:    issue.Metadata,
+		DeferUntil:  issue.DeferUntil,
+		Notes:       issue.Notes,
+		SpecID:      issue.SpecID,
 	}
 
 	evt, err := newEvent(EventCreate, issue.ID, data)
@@ -175,6 +208,49 @@ func buildCreateEvent(issue *Issue) (Event, error) {
 	return evt, nil
 }
 
+// importComments appends comment events for incoming comments the target
+// does not have yet, matched on author, text and time. Comments are renumbered
+// in the target's sequence and replies are remapped to the new numbers.
+func importComments(target *Issue, incoming []*Comment) ([]Event, error) {
+	if target == nil {
+		return nil, nil
+	}
+	renumbered := make(map[int]int, len(incoming))
+	var events []Event
+	for _, comment := range incoming {
+		if existing := findMatchingComment(target, comment); existing != nil {
+			renumbered[comment.ID] = existing.ID
+			continue
+		}
+		data := CommentEventData{CommentID: nextCommentID(target), Text: comment.Text, ReplyTo: renumbered[comment.ReplyTo]}
+		evt, err := newEvent(EventComment, target.ID, data)
+		if err != nil {
+			return nil, err
+		}
+		if comment.Author != "" {
+			evt.Actor = comment.Author
+		}
+		if !comment.CreatedAt.IsZero() {
+			evt.Timestamp = comment.CreatedAt
+		}
+		events = append(events, evt)
+		renumbered[comment.ID] = data.CommentID
+		target.Comments = append(target.Comments, &Comment{
+			ID: data.CommentID, IssueID: target.ID, Author: evt.Actor, Text: data.Text, CreatedAt: evt.Timestamp, ReplyTo: data.ReplyTo,
+		})
+	}
+	return events, nil
+}
+
+func findMatchingComment(issue *Issue, comment *Comment) *Comment {
+	for _, existing := range issue.Comments {
+		if existing.Author == comment.Author && existing.Text == comment.Text && existing.CreatedAt.Equal(comment.CreatedAt) {
+			return existing
+		}
+	}
+	return nil
+}
+
 func buildUpdateFields(existing *Issue, incoming *Issue) (map[string]json.RawMessage, error) {
 	fields := make(map[string]json.RawMessage)
 
@@ -192,6 +268,20 @@ func buildUpdateFields(existing *Issue, incoming *Issue) (map[string]json.RawMes
 		}
 		fields["description"] = raw
 	}
+	if existing.Notes != incoming.Notes {
+		raw, err := json.Marshal(incoming.Notes)
+		if err != nil {
+			return nil, err
+		}
+		fields["notes"] = raw
+	}
+	if existing.SpecID != incoming.SpecID {
+		raw, err := json.Marshal(incoming.SpecID)
+		if err != nil {
+			return nil, err
+		}
+		fields["spec_id"] = raw
+	}
 	if existing.Status != incoming.Status {
 		raw, err := json.Marshal(incoming.Status)
 		if err != nil {
@@ -259,6 +349,14 @@ func applyUpdateFields(issue *Issue, fields map[string]json.RawMessage) {
 			var v string
 			_ = json.Unmarshal(value, &v)
 			issue.Description = v
+		case "notes":
+			var v string
+			_ = json.Unmarshal(value, &v)
+			issue.Notes = v
+		case "spec_id":
+			var v string
+			_ = json.Unmarshal(value, &v)
+			issue.SpecID = v
 		case "priority":
 			var v int
 			_ = json.Unmarshal(value, &v)
@@ -304,12 +402,23 @@ func cloneIssue(issue *Issue) *Issue {
 
 	cloned := *issue
 	cloned.Labels = append([]string(nil), issue.Labels...)
+	if issue.Lease != nil {
+		lease := *issue.Lease
+		cloned.Lease = &lease
+	}
 	if issue.Metadata != nil {
 		cloned.Metadata = make(map[string]json.RawMessage, len(issue.Metadata))
 		for k, v := range issue.Metadata {
 			cloned.Metadata[k] = cloneRawMessage(v)
 		}
 	}
+	if issue.Comments != nil {
+		cloned.Comments = make([]*Comment, 0, len(issue.Comments))
+		for _, comment := range issue.Comments {
+			c := *comment
+			cloned.Comments = append(cloned.Comments, &c)
+		}
+	}
 	if issue.Dependencies != nil {
 		cloned.Dependencies = make([]*Dependency, 0, len(issue.Dependencies))
 		for _, dep := range issue.Dependencies {
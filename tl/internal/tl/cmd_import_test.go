@@ -1,10 +1,11 @@
 // ABOUTME: Tests for import command behavior when ingesting beads JSONL fixtures.
-// ABOUTME: Verifies dedup counts, ID preservation, and unknown metadata retention.
+// ABOUTME: Verifies dedup counts, ID preservation, unknown metadata retention, and cycle warnings.
 
 package tl
 
 import (
 	"encoding/json"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -91,3 +92,68 @@ func TestImportDeduplicatesByContentHash(t *testing.T) {
 	require.NoError(t, runImport(cmdSecond, nil))
 	assert.Equal(t, "Imported 0, Updated 0, Skipped 5 from testdata/beads_sample.jsonl\n", out.String())
 }
+
+func writeCyclicBeadsFixture(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cyclic.jsonl")
+	lines := []string{
+		`{"id":"bd-x1","title":"X1","status":"open","priority":1,"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z","dependencies":[{"issue_id":"bd-x1","depends_on_id":"bd-x2","type":"blocks"}]}`,
+		`{"id":"bd-x2","title":"X2","status":"open","priority":1,"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z","dependencies":[{"issue_id":"bd-x2","depends_on_id":"bd-x1","type":"waits-for"}]}`,
+	}
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+	return path
+}
+
+func setImportGlobals(t *testing.T, root, from string, strict bool) {
+	t.Helper()
+	originalDir := tlDirFlag
+	originalJSON := jsonOutput
+	originalFrom := importFromPath
+	originalStrict := importStrict
+	t.Cleanup(func() {
+		tlDirFlag = originalDir
+		jsonOutput = originalJSON
+		importFromPath = originalFrom
+		importStrict = originalStrict
+	})
+
+	tlDirFlag = root
+	jsonOutput = false
+	importFromPath = from
+	importStrict = strict
+}
+
+func TestImportWarnsOnIntroducedCycle(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, initDir(root))
+	setImportGlobals(t, root, writeCyclicBeadsFixture(t), false)
+
+	var out, errOut strings.Builder
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+
+	require.NoError(t, runImport(cmd, nil))
+	assert.Contains(t, out.String(), "Imported 2")
+	assert.Equal(t, "warning: import introduces dependency cycle: bd-x1 -[blocks]-> bd-x2 -[waits-for]-> bd-x1\n", errOut.String())
+
+	graph, err := loadGraph(filepath.Join(root, tlDirName))
+	require.NoError(t, err)
+	assert.Len(t, graph.Tasks, 2)
+}
+
+func TestImportStrictRejectsCycle(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, initDir(root))
+	setImportGlobals(t, root, writeCyclicBeadsFixture(t), true)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&strings.Builder{})
+	err := runImport(cmd, nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrCycle)
+
+	graph, loadErr := loadGraph(filepath.Join(root, tlDirName))
+	require.NoError(t, loadErr)
+	assert.Empty(t, graph.Tasks)
+}
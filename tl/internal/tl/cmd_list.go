@@ -1,4 +1,4 @@
-// ABOUTME: List command — displays tasks with optional status/type/assignee/priority filters.
+// ABOUTME: List command — displays tasks with optional status/type/assignee/priority/stale-claim/deferred filters.
 // ABOUTME: Implements `tl list` to query the task graph and output matching issues.
 
 package tl
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -18,6 +19,9 @@ var (
 	listAssignee string
 	listPriority int
 	listLimit    int
+
+	listStaleClaims bool
+	listDeferred    bool
 )
 
 func init() {
@@ -26,6 +30,9 @@ func init() {
 	listCmd.Flags().StringVar(&listAssignee, "assignee", "", "Filter by assignee")
 	listCmd.Flags().IntVar(&listPriority, "priority", -1, "Filter by priority (-1 = no filter)")
 	listCmd.Flags().IntVar(&listLimit, "limit", 0, "Maximum number of results (0 = all)")
+	listCmd.Flags().BoolVar(&listStaleClaims, "stale-claims", false, "Only in-progress tasks whose claim lease has expired")
+
+	listCmd.Flags().BoolVar(&listDeferred, "deferred", false, "Only deferred tasks, soonest wake-up first")
 
 	listCmd.RunE = runList
 }
@@ -43,21 +50,32 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	issues := filterIssues(graph)
-	sortIssues(issues)
+	if listDeferred {
+		sortByWakeUp(issues)
+	} else {
+		sortIssues(issues)
+	}
 
 	if listLimit > 0 && len(issues) > listLimit {
 		issues = issues[:listLimit]
 	}
 
 	if opts.JSON {
-		return printListJSON(cmd, issues)
+		return printListJSON(cmd, graph, issues)
 	}
-	return printListText(cmd, issues)
+	return printListText(cmd, graph, issues)
 }
 
 func filterIssues(graph *Graph) []*Issue {
 	var result []*Issue
+	now := time.Now()
 	for _, issue := range graph.Tasks {
+		if listStaleClaims && !leaseExpired(issue, now) {
+			continue
+		}
+		if listDeferred && issue.Status != StatusDeferred && !isDeferred(issue, now) {
+			continue
+		}
 		if listStatus != "" && string(issue.Status) != listStatus {
 			continue
 		}
@@ -84,11 +102,25 @@ func sortIssues(issues []*Issue) {
 	})
 }
 
-func printListJSON(cmd *cobra.Command, issues []*Issue) error {
-	if issues == nil {
-		issues = []*Issue{}
+// sortByWakeUp orders deferred issues by wake-up time; those deferred
+// indefinitely come last.
+func sortByWakeUp(issues []*Issue) {
+	sortIssues(issues)
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i].DeferUntil, issues[j].DeferUntil
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.Before(*b)
+	})
+}
+
+func printListJSON(cmd *cobra.Command, graph *Graph, issues []*Issue) error {
+	rows := make([]issueWithProgress, 0, len(issues))
+	for _, issue := range issues {
+		rows = append(rows, withProgress(graph, issue))
 	}
-	data, err := json.Marshal(issues)
+	data, err := json.Marshal(rows)
 	if err != nil {
 		return err
 	}
@@ -96,14 +128,33 @@ func printListJSON(cmd *cobra.Command, issues []*Issue) error {
 	return nil
 }
 
-func printListText(cmd *cobra.Command, issues []*Issue) error {
+func printListText(cmd *cobra.Command, graph *Graph, issues []*Issue) error {
 	w := cmd.OutOrStdout()
 	for _, issue := range issues {
-		fmt.Fprintf(w, "%s [%s] P%d %s\n",
+		fmt.Fprintf(w, "%s [%s] P%d %s",
 			issue.ID,
 			string(issue.Status),
 			issue.Priority,
 			strings.TrimSpace(issue.Title))
+		if issue.Lease != nil && issue.Status == StatusInProgress {
+			state := "expires"
+			if leaseExpired(issue, time.Now()) {
+				state = "expired"
+			}
+			fmt.Fprintf(w, " (%s, lease %s %s)", issue.Assignee, state, issue.Lease.ExpiresAt.Format(time.RFC3339))
+		}
+		if issue.DeferUntil != nil && (issue.Status == StatusDeferred || isDeferred(issue, time.Now())) {
+			state := "wakes"
+			if deferLapsed(issue, time.Now()) {
+				state = "woke"
+			}
+			fmt.Fprintf(w, " (%s %s)", state, issue.DeferUntil.Format(time.RFC3339))
+		}
+		if issue.IssueType == TypeEpic {
+			progress := computeEpicProgress(graph, issue)
+			fmt.Fprintf(w, " (%d%%, %d/%d closed; %s)", progress.Percent, progress.Closed, progress.Total, formatStatusCounts(progress.ByStatus))
+		}
+		fmt.Fprintln(w)
 	}
 	return nil
 }
@@ -60,6 +60,8 @@ func resetListGlobals(dir string) {
 	listAssignee = ""
 	listPriority = -1
 	listLimit = 0
+	listStaleClaims = false
+	listDeferred = false
 }
 
 func runListCapture(t *testing.T) (string, error) {
@@ -0,0 +1,177 @@
+// ABOUTME: Log command — prints the event history of one task or the whole repository.
+// ABOUTME: Implements `tl log [<id>] [--custody]`, summarizing each event, including claim handoffs.
+
+package tl
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	logCustody bool
+	logLimit   int
+)
+
+func init() {
+	logCmd.Args = cobra.MaximumNArgs(1)
+	logCmd.Flags().BoolVar(&logCustody, "custody", false, "Only show custody changes (claim, release, handoff, lease expiry)")
+	logCmd.Flags().IntVar(&logLimit, "limit", 0, "Show only the most recent N events (0 = all)")
+	logCmd.RunE = runLog
+}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	dir, err := tlDir(GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag})
+	if err != nil {
+		return err
+	}
+
+	events, err := readEvents(filepath.Join(dir, eventsFileName))
+	if err != nil {
+		return err
+	}
+
+	id := ""
+	if len(args) == 1 {
+		id = args[0]
+		graph, err := replayEvents(events)
+		if err != nil {
+			return err
+		}
+		if _, ok := graph.Tasks[id]; !ok {
+			return fmt.Errorf("issue %q: %w", id, ErrNotFound)
+		}
+	}
+
+	selected := make([]Event, 0)
+	for _, evt := range events {
+		if id != "" && evt.ID != id {
+			continue
+		}
+		if logCustody && !isCustodyEvent(evt.Type) {
+			continue
+		}
+		selected = append(selected, evt)
+	}
+	if logLimit > 0 && len(selected) > logLimit {
+		selected = selected[len(selected)-logLimit:]
+	}
+
+	if jsonOutput {
+		data, err := json.Marshal(selected)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	for _, evt := range selected {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %s %s: %s\n", evt.Timestamp.UTC().Format(time.RFC3339), evt.ID, evt.Actor, describeEvent(evt))
+	}
+	return nil
+}
+
+// isCustodyEvent reports whether an event type changes who holds a task.
+func isCustodyEvent(eventType string) bool {
+	switch eventType {
+	case EventClaim, EventRelease, EventHandoff, EventLeaseExpired:
+		return true
+	}
+	return false
+}
+
+// describeEvent renders a one-line human summary of an event. Unknown or
+// malformed events fall back to their type.
+func describeEvent(evt Event) string {
+	switch evt.Type {
+	case EventCreate:
+		var data CreateEventData
+		if json.Unmarshal(evt.Data, &data) == nil {
+			return fmt.Sprintf("created %q", data.Title)
+		}
+	case EventUpdate:
+		var data UpdateEventData
+		if json.Unmarshal(evt.Data, &data) == nil {
+			fields := make([]string, 0, len(data.Fields))
+			for field, value := range data.Fields {
+				fields = append(fields, field+"="+string(value))
+			}
+			sort.Strings(fields)
+			return "updated " + strings.Join(fields, " ")
+		}
+	case EventClose:
+		var data CloseEventData
+		if json.Unmarshal(evt.Data, &data) == nil && data.Reason != "" {
+			return fmt.Sprintf("closed (%s)", data.Reason)
+		}
+		return "closed"
+	case EventReopen:
+		return "reopened"
+	case EventDepAdd:
+		var data DepAddEventData
+		if json.Unmarshal(evt.Data, &data) == nil {
+			return fmt.Sprintf("added %s dependency on %s", data.DepType, data.DependsOnID)
+		}
+	case EventDepRemove:
+		var data DepRemoveEventData
+		if json.Unmarshal(evt.Data, &data) == nil {
+			return "removed dependency on " + data.DependsOnID
+		}
+	case EventClaim:
+		var data ClaimEventData
+		if json.Unmarshal(evt.Data, &data) == nil {
+			if data.Lease != "" {
+				return fmt.Sprintf("claimed by %s (lease %s)", data.Agent, data.Lease)
+			}
+			return "claimed by " + data.Agent
+		}
+	case EventHeartbeat:
+		var data HeartbeatEventData
+		if json.Unmarshal(evt.Data, &data) == nil {
+			return fmt.Sprintf("lease renewed by %s (%s)", data.Agent, data.Lease)
+		}
+	case EventLeaseExpired:
+		var data LeaseExpiredEventData
+		if json.Unmarshal(evt.Data, &data) == nil {
+			return fmt.Sprintf("lease held by %s expired at %s", data.Agent, data.ExpiredAt.UTC().Format(time.RFC3339))
+		}
+	case EventRelease:
+		var data ReleaseEventData
+		if json.Unmarshal(evt.Data, &data) == nil {
+			if data.Note != "" {
+				return fmt.Sprintf("released by %s: %s", data.Agent, data.Note)
+			}
+			return "released by " + data.Agent
+		}
+	case EventHandoff:
+		var data HandoffEventData
+		if json.Unmarshal(evt.Data, &data) == nil {
+			return fmt.Sprintf("handed off from %s to %s: %s", data.From, data.To, data.Reason)
+		}
+	case EventDefer:
+		var data DeferEventData
+		if json.Unmarshal(evt.Data, &data) == nil {
+			return "deferred until " + data.Until.UTC().Format(time.RFC3339)
+		}
+	case EventUndefer:
+		return "undeferred"
+	case EventComment:
+		var data CommentEventData
+		if json.Unmarshal(evt.Data, &data) == nil {
+			if data.ReplyTo != 0 {
+				return fmt.Sprintf("comment #%d in reply to #%d", data.CommentID, data.ReplyTo)
+			}
+			return fmt.Sprintf("comment #%d", data.CommentID)
+		}
+	case EventNote:
+		return "added a note"
+	}
+	return evt.Type
+}
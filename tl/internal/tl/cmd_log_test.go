@@ -0,0 +1,69 @@
+// ABOUTME: Tests tl log event history rendering and filters.
+// ABOUTME: Checks per-task filtering, --custody, --limit, JSON output and unknown IDs.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seedLogRepo(t *testing.T) string {
+	t.Helper()
+	ts := time.Date(2026, 2, 8, 9, 0, 0, 0, time.UTC)
+	handoff, err := json.Marshal(HandoffEventData{From: "agent-1", To: "agent-2", Reason: "crashed"})
+	require.NoError(t, err)
+	release, err := json.Marshal(ReleaseEventData{Agent: "agent-2", Note: "blocked on review"})
+	require.NoError(t, err)
+	return seedCommandRepoWithEvents(t,
+		createIssueEvent(t, "tl-a", "Alpha", StatusOpen, 1, ts),
+		createIssueEvent(t, "tl-b", "Beta", StatusOpen, 1, ts.Add(time.Minute)),
+		claimEventAt(t, "tl-a", "agent-1", "30m", ts.Add(2*time.Minute)),
+		Event{Type: EventHandoff, ID: "tl-a", Timestamp: ts.Add(3 * time.Minute), Actor: "agent-2", Data: handoff},
+		Event{Type: EventRelease, ID: "tl-a", Timestamp: ts.Add(4 * time.Minute), Actor: "agent-2", Data: release},
+		closeIssueEvent(t, "tl-b", "done", ts.Add(5*time.Minute)),
+	)
+}
+
+func setLogGlobals(t *testing.T, dir string, json, custody bool, limit int) {
+	t.Helper()
+	setCommandGlobals(t, dir, json)
+	prevCustody, prevLimit := logCustody, logLimit
+	t.Cleanup(func() { logCustody, logLimit = prevCustody, prevLimit })
+	logCustody, logLimit = custody, limit
+}
+
+func TestLogShowsTaskHistory(t *testing.T) {
+	setLogGlobals(t, seedLogRepo(t), false, false, 0)
+
+	cmd := newTestCommand()
+	require.NoError(t, runLog(cmd, []string{"tl-a"}))
+	assert.Equal(t,
+		"2026-02-08T09:00:00Z tl-a test: created \"Alpha\"\n"+
+			"2026-02-08T09:02:00Z tl-a agent-1: claimed by agent-1 (lease 30m)\n"+
+			"2026-02-08T09:03:00Z tl-a agent-2: handed off from agent-1 to agent-2: crashed\n"+
+			"2026-02-08T09:04:00Z tl-a agent-2: released by agent-2: blocked on review\n",
+		cmd.OutOrStdout().(*bytes.Buffer).String())
+}
+
+func TestLogCustodyAndLimit(t *testing.T) {
+	setLogGlobals(t, seedLogRepo(t), true, true, 2)
+
+	cmd := newTestCommand()
+	require.NoError(t, runLog(cmd, nil))
+	var events []Event
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &events))
+	require.Len(t, events, 2)
+	assert.Equal(t, EventHandoff, events[0].Type)
+	assert.Equal(t, EventRelease, events[1].Type)
+}
+
+func TestLogUnknownIssue(t *testing.T) {
+	setLogGlobals(t, seedLogRepo(t), false, false, 0)
+	assert.ErrorIs(t, runLog(newTestCommand(), []string{"tl-nope"}), ErrNotFound)
+}
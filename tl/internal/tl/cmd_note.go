@@ -0,0 +1,68 @@
+// ABOUTME: Note command — appends a timestamped, actor-attributed entry to a task's notes.
+// ABOUTME: Implements `tl note <id> [text|-]` (text from stdin when omitted); entries accumulate in Issue.Notes.
+
+package tl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	noteCmd.Args = cobra.RangeArgs(1, 2)
+	noteCmd.RunE = runNote
+}
+
+func runNote(cmd *cobra.Command, args []string) error {
+	id := args[0]
+	text := "-"
+	if len(args) == 2 {
+		text = args[1]
+	}
+	if text == "-" {
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		text = string(data)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return validationError("note text is empty")
+	}
+
+	opts := GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag}
+	dir, err := tlDir(opts)
+	if err != nil {
+		return err
+	}
+
+	var noted Issue
+	err = mutate(dir, func(g *Graph) ([]Event, error) {
+		issue, ok := g.Tasks[id]
+		if !ok {
+			return nil, fmt.Errorf("issue %q: %w", id, ErrNotFound)
+		}
+		evt, err := newEvent(EventNote, id, NoteEventData{Text: text})
+		if err != nil {
+			return nil, err
+		}
+		if err := g.applyEvent(evt); err != nil {
+			return nil, err
+		}
+		noted = *issue
+		return []Event{evt}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		return printIssueJSON(cmd, &noted)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Added note to %s\n", id)
+	return nil
+}
@@ -0,0 +1,75 @@
+// ABOUTME: Tests `tl note`: appended, attributed entries, stdin input and rendering in tl show.
+// ABOUTME: Also checks notes survive the beads round trip as the flat notes field.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoteAppendsAttributedEntries(t *testing.T) {
+	dir := seedCommandRepoWithEvents(t, createIssueEvent(t, "tl-a", "Task", StatusOpen, 1, time.Now().UTC()))
+	setCommandGlobals(t, dir, false)
+	t.Setenv("TL_ACTOR", "agent-1")
+
+	cmd := newTestCommand()
+	require.NoError(t, runNote(cmd, []string{"tl-a", "tried X, failed because Y"}))
+	assert.Equal(t, "Added note to tl-a\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	t.Setenv("TL_ACTOR", "agent-2")
+	cmd = newTestCommand()
+	cmd.SetIn(strings.NewReader("switched to Z\n"))
+	require.NoError(t, runNote(cmd, []string{"tl-a"}))
+
+	assert.ErrorIs(t, runNote(newTestCommand(), []string{"tl-a", " "}), ErrValidation)
+	assert.ErrorIs(t, runNote(newTestCommand(), []string{"tl-nope", "hi"}), ErrNotFound)
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	lines := strings.Split(graph.Tasks["tl-a"].Notes, "\n")
+	require.Len(t, lines, 2)
+	assert.Regexp(t, `^\[\d{4}-\d\d-\d\dT\d\d:\d\d:\d\dZ agent-1\] tried X, failed because Y$`, lines[0])
+	assert.Regexp(t, `^\[\S+ agent-2\] switched to Z$`, lines[1])
+
+	cmd = newTestCommand()
+	require.NoError(t, runShow(cmd, []string{"tl-a"}))
+	assert.Contains(t, cmd.OutOrStdout().(*bytes.Buffer).String(), "notes:\n  "+lines[0]+"\n  "+lines[1]+"\n")
+}
+
+func TestNotesRoundTripThroughBeads(t *testing.T) {
+	ts := time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)
+	note, err := json.Marshal(NoteEventData{Text: "found the root cause"})
+	require.NoError(t, err)
+	source := seedCommandRepoWithEvents(t,
+		createIssueEvent(t, "tl-a", "Task", StatusOpen, 1, ts),
+		Event{Type: EventNote, ID: "tl-a", Timestamp: ts.Add(time.Minute), Actor: "agent-1", Data: note},
+	)
+	graph, err := loadGraph(source)
+	require.NoError(t, err)
+
+	line, err := issueToBeadsJSON(graph.Tasks["tl-a"])
+	require.NoError(t, err)
+	var beads map[string]any
+	require.NoError(t, json.Unmarshal(line, &beads))
+	assert.Equal(t, "[2026-03-03T09:01:00Z agent-1] found the root cause", beads["notes"])
+
+	from := filepath.Join(t.TempDir(), "issues.jsonl")
+	require.NoError(t, os.WriteFile(from, append(line, '\n'), 0644))
+	root := t.TempDir()
+	require.NoError(t, initDir(root))
+	setImportGlobals(t, root, from, false)
+	require.NoError(t, runImport(newTestCommand(), nil))
+
+	imported, err := loadGraph(filepath.Join(root, tlDirName))
+	require.NoError(t, err)
+	assert.Equal(t, beads["notes"], imported.Tasks["tl-a"].Notes)
+}
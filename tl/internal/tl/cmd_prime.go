@@ -0,0 +1,72 @@
+// ABOUTME: Prime command — prints the session-start workflow briefing for an agent.
+// ABOUTME: Implements `tl prime [--agent] [--limit]`, customizable through .tl/prime.md; suitable for session hooks.
+
+package tl
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	primeAgent    string
+	primeLimit    int
+	primeTemplate bool
+)
+
+func init() {
+	primeCmd.Args = cobra.NoArgs
+	primeCmd.Flags().StringVar(&primeAgent, "agent", resolveActor(), "Agent whose claims are listed and whose profile filters the ready queue")
+	primeCmd.Flags().IntVar(&primeLimit, "limit", 5, "Number of ready and recently closed tasks to show")
+	primeCmd.Flags().BoolVar(&primeTemplate, "template", false, "Print the built-in template (a starting point for .tl/prime.md) and exit")
+	primeCmd.RunE = runPrime
+}
+
+func runPrime(cmd *cobra.Command, args []string) error {
+	if primeTemplate {
+		fmt.Fprint(cmd.OutOrStdout(), defaultPrimeTemplate)
+		return nil
+	}
+	if primeLimit < 0 {
+		return validationError("--limit must not be negative")
+	}
+
+	dir, err := tlDir(GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag})
+	if err != nil {
+		return err
+	}
+	graph, err := loadGraph(dir)
+	if err != nil {
+		return err
+	}
+	agent := primeAgent
+	if agent == "" {
+		agent = resolveActor()
+	}
+	profile, err := defaultAgentProfile(cmd, dir, agent)
+	if err != nil {
+		return err
+	}
+	data, err := collectPrime(dir, graph, agent, profile, primeLimit, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		out, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+		return nil
+	}
+	out, err := renderPrime(dir, data)
+	if err != nil {
+		return err
+	}
+	_, err = cmd.OutOrStdout().Write(out)
+	return err
+}
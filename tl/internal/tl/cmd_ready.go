@@ -1,5 +1,5 @@
 // ABOUTME: Ready command implementation for selecting actionable open tasks.
-// ABOUTME: Computes blocked/deferred/pinned filters and prints ready queue in text or JSON.
+// ABOUTME: Filters the ready queue by label/type/assignee/agent profile, orders it by a scoring strategy, and with --explain says why open tasks are excluded.
 
 package tl
 
@@ -13,15 +13,66 @@ import (
 )
 
 type readyIssue struct {
+	ID        string    `json:"id"`
+	Priority  int       `json:"priority"`
+	Title     string    `json:"title"`
+	IssueType IssueType `json:"issue_type,omitempty"`
+	Status    Status    `json:"status,omitempty"`
+	Labels    []string  `json:"labels,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Parent    string    `json:"parent,omitempty"`
+	Score     *float64  `json:"score,omitempty"`
+}
+
+// excludedIssue is an open task left out of the ready queue, with the reason.
+type excludedIssue struct {
 	ID       string `json:"id"`
 	Priority int    `json:"priority"`
 	Title    string `json:"title"`
+	Reason   string `json:"reason"`
 }
 
+var (
+	readyLabel             string
+	readyType              string
+	readyAssignee          string
+	readyUnassigned        bool
+	readyLimit             int
+	readyIncludeInProgress bool
+	readyExplain           bool
+	readyStrategy          string
+	readyAgent             string
+)
+
 func init() {
+	readyCmd.Flags().StringVar(&readyLabel, "label", "", "Only tasks with this label")
+	readyCmd.Flags().StringVar(&readyType, "type", "", "Only tasks of this issue type")
+	readyCmd.Flags().StringVar(&readyAssignee, "assignee", "", "Only tasks assigned to this agent")
+	readyCmd.Flags().BoolVar(&readyUnassigned, "unassigned", false, "Only tasks with no assignee")
+	readyCmd.Flags().IntVar(&readyLimit, "limit", 0, "Maximum number of results (0 = all)")
+	readyCmd.Flags().BoolVar(&readyIncludeInProgress, "include-in-progress", false, "Also list unblocked tasks that are already in progress")
+	readyCmd.Flags().BoolVar(&readyExplain, "explain", false, "Explain why each open task that is not ready was excluded")
+	readyCmd.Flags().StringVar(&readyStrategy, "strategy", "", "Ordering strategy: strict, aging, critical-path-first or downstream-unblock-count-first (default from config)")
+	readyCmd.Flags().StringVar(&readyAgent, "agent", "", "Only tasks this agent's profile in agents.yaml qualifies it for")
 	readyCmd.RunE = runReady
 }
 
+func newReadyIssue(issue *Issue) readyIssue {
+	row := readyIssue{
+		ID:        issue.ID,
+		Priority:  issue.Priority,
+		Title:     issue.Title,
+		IssueType: issue.IssueType,
+		Status:    issue.Status,
+		Labels:    issue.Labels,
+		CreatedAt: issue.CreatedAt,
+	}
+	if parents := parentIDs(issue); len(parents) > 0 {
+		row.Parent = parents[0]
+	}
+	return row
+}
+
 func runReady(cmd *cobra.Command, args []string) error {
 	dir, err := tlDir(GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag})
 	if err != nil {
@@ -33,15 +84,66 @@ func runReady(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return err
+	}
+	strategy, err := resolveStrategy(readyStrategy, cfg)
+	if err != nil {
+		return err
+	}
+
+	filter := readyFilter{
+		Label:       readyLabel,
+		Type:        readyType,
+		MaxPriority: -1,
+		Assignee:    readyAssignee,
+		Unassigned:  readyUnassigned,
+	}
+	if readyAgent != "" {
+		if filter.Profile, err = loadAgentProfile(dir, readyAgent); err != nil {
+			return err
+		}
+	}
+	now := time.Now()
 	blockedSet := computeBlockedSet(graph)
-	ready := collectReadyIssues(graph, blockedSet, time.Now())
+
+	var matched []*Issue
+	for _, issue := range collectReady(graph, blockedSet, now, readyIncludeInProgress) {
+		if filter.matches(issue, now) {
+			matched = append(matched, issue)
+		}
+	}
+	scored, err := scoreReady(graph, matched, strategy, cfg, now)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]readyIssue, 0)
+	for _, issue := range scored {
+		if readyLimit > 0 && len(rows) == readyLimit {
+			break
+		}
+		row := newReadyIssue(issue.Issue)
+		score := issue.Score
+		row.Score = &score
+		rows = append(rows, row)
+	}
+
+	var excluded []excludedIssue
+	if readyExplain {
+		excluded = explainNotReady(graph, blockedSet, filter, now)
+	}
 
 	if jsonOutput {
-		rows := make([]readyIssue, 0, len(ready))
-		for _, issue := range ready {
-			rows = append(rows, readyIssue{ID: issue.ID, Priority: issue.Priority, Title: issue.Title})
+		var payload any = rows
+		if readyExplain {
+			payload = struct {
+				Ready    []readyIssue    `json:"ready"`
+				Excluded []excludedIssue `json:"excluded"`
+			}{rows, excluded}
 		}
-		data, err := json.Marshal(rows)
+		data, err := json.Marshal(payload)
 		if err != nil {
 			return err
 		}
@@ -49,9 +151,65 @@ func runReady(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	for _, issue := range ready {
-		fmt.Fprintf(cmd.OutOrStdout(), "%s P%d %s\n", issue.ID, issue.Priority, strings.TrimSpace(issue.Title))
+	w := cmd.OutOrStdout()
+	for _, row := range rows {
+		fmt.Fprintf(w, "%s P%d %s", row.ID, row.Priority, strings.TrimSpace(row.Title))
+		if row.Status == StatusInProgress {
+			fmt.Fprintf(w, " [%s]", row.Status)
+		}
+		fmt.Fprintln(w)
+	}
+	if readyExplain && len(excluded) > 0 {
+		fmt.Fprintln(w, "Not ready:")
+		for _, row := range excluded {
+			fmt.Fprintf(w, "  %s P%d %s: %s\n", row.ID, row.Priority, strings.TrimSpace(row.Title), row.Reason)
+		}
 	}
 
 	return nil
 }
+
+// explainNotReady lists open and deferred tasks matching filter that the ready queue
+// leaves out, in ready-queue order.
+func explainNotReady(graph *Graph, blockedSet map[string]bool, filter readyFilter, now time.Time) []excludedIssue {
+	var open []*Issue
+	for _, issue := range graph.Tasks {
+		if (issue.Status == StatusOpen || issue.Status == StatusDeferred) && filter.matches(issue, now) {
+			open = append(open, issue)
+		}
+	}
+	sortIssues(open)
+
+	rows := make([]excludedIssue, 0)
+	for _, issue := range open {
+		reason := notReadyReason(graph, issue, blockedSet, now)
+		if reason == "" {
+			continue
+		}
+		rows = append(rows, excludedIssue{ID: issue.ID, Priority: issue.Priority, Title: issue.Title, Reason: reason})
+	}
+	return rows
+}
+
+// notReadyReason mirrors the checks in collectReady for an open or deferred issue and
+// returns "" when the issue is ready.
+func notReadyReason(graph *Graph, issue *Issue, blockedSet map[string]bool, now time.Time) string {
+	var reasons []string
+	if blockedSet[issue.ID] {
+		blockers, _ := blockersForIssue(issue, graph, blockedSet)
+		reasons = append(reasons, "blocked by "+strings.Join(blockers, ", "))
+	}
+	switch {
+	case isDeferred(issue, now):
+		reasons = append(reasons, "deferred until "+issue.DeferUntil.UTC().Format(time.RFC3339))
+	case issue.Status == StatusDeferred && issue.DeferUntil == nil:
+		reasons = append(reasons, "deferred indefinitely")
+	}
+	if issue.Pinned {
+		reasons = append(reasons, "pinned")
+	}
+	if awaitsChildren(graph, issue) {
+		reasons = append(reasons, "epic with open children")
+	}
+	return strings.Join(reasons, "; ")
+}
@@ -1,5 +1,5 @@
 // ABOUTME: Tests `tl ready` command behavior against real event-log graph replays.
-// ABOUTME: Validates blocked filtering and blocker-closure promotion into ready queue.
+// ABOUTME: Validates blocked filtering, blocker-closure promotion, filters, --explain and rich JSON rows.
 
 package tl
 
@@ -120,3 +120,125 @@ func statusUpdateEvent(t *testing.T, id string, status Status, ts time.Time) Eve
 	require.NoError(t, err)
 	return Event{Type: EventUpdate, ID: id, Timestamp: ts, Actor: "test", Data: data}
 }
+
+func setReadyGlobals(t *testing.T, dir string, json bool) {
+	t.Helper()
+	setCommandGlobals(t, dir, json)
+	prev := []any{readyLabel, readyType, readyAssignee, readyUnassigned, readyLimit, readyIncludeInProgress, readyExplain, readyStrategy, readyAgent}
+	t.Cleanup(func() {
+		readyLabel, readyType, readyAssignee = prev[0].(string), prev[1].(string), prev[2].(string)
+		readyUnassigned, readyLimit = prev[3].(bool), prev[4].(int)
+		readyIncludeInProgress, readyExplain = prev[5].(bool), prev[6].(bool)
+		readyStrategy, readyAgent = prev[7].(string), prev[8].(string)
+	})
+	readyLabel, readyType, readyAssignee, readyStrategy, readyAgent = "", "", "", "", ""
+	readyUnassigned, readyLimit = false, 0
+	readyIncludeInProgress, readyExplain = false, false
+}
+
+func seedReadyFilterRepo(t *testing.T) string {
+	t.Helper()
+	ts := time.Date(2026, 2, 9, 9, 0, 0, 0, time.UTC)
+	bug, err := json.Marshal(CreateEventData{Title: "Crash on save", Status: string(StatusOpen), Priority: 1, IssueType: string(TypeBug), Labels: []string{"ui"}})
+	require.NoError(t, err)
+	return seedCommandRepoWithEvents(t,
+		createIssueEvent(t, "tl-epic", "Epic", StatusOpen, 3, ts),
+		Event{Type: EventCreate, ID: "tl-bug", Timestamp: ts.Add(time.Minute), Actor: "test", Data: bug},
+		createIssueEvent(t, "tl-task", "Write docs", StatusOpen, 2, ts.Add(2*time.Minute)),
+		createIssueEvent(t, "tl-mine", "Mine", StatusOpen, 0, ts.Add(3*time.Minute)),
+		createIssueEvent(t, "tl-wait", "Waiting", StatusOpen, 0, ts.Add(4*time.Minute)),
+		depAddEvent(t, "tl-bug", "tl-task", DepRelated, ts.Add(5*time.Minute)),
+		claimEventAt(t, "tl-mine", "agent-1", "", ts.Add(6*time.Minute)),
+		depAddEvent(t, "tl-wait", "tl-task", DepBlocks, ts.Add(7*time.Minute)),
+	)
+}
+
+func TestReadyFilters(t *testing.T) {
+	dir := seedReadyFilterRepo(t)
+	run := func() string {
+		cmd := newTestCommand()
+		require.NoError(t, runReady(cmd, nil))
+		return cmd.OutOrStdout().(*bytes.Buffer).String()
+	}
+
+	setReadyGlobals(t, dir, false)
+	assert.Equal(t, "tl-bug P1 Crash on save\ntl-task P2 Write docs\ntl-epic P3 Epic\n", run())
+
+	readyLabel = "ui"
+	assert.Equal(t, "tl-bug P1 Crash on save\n", run())
+
+	readyLabel, readyType = "", string(TypeBug)
+	assert.Equal(t, "tl-bug P1 Crash on save\n", run())
+
+	readyType, readyLimit = "", 2
+	assert.Equal(t, "tl-bug P1 Crash on save\ntl-task P2 Write docs\n", run())
+
+	readyLimit, readyIncludeInProgress = 0, true
+	assert.Equal(t, "tl-mine P0 Mine [in_progress]\ntl-bug P1 Crash on save\ntl-task P2 Write docs\ntl-epic P3 Epic\n", run())
+
+	readyAssignee = "agent-1"
+	assert.Equal(t, "tl-mine P0 Mine [in_progress]\n", run())
+
+	readyAssignee, readyUnassigned = "", true
+	assert.NotContains(t, run(), "tl-mine")
+}
+
+func TestReadyExplainAndRichJSON(t *testing.T) {
+	dir := seedReadyFilterRepo(t)
+	require.NoError(t, appendEventsToFile(filepath.Join(dir, eventsFileName), []Event{
+		depAddEvent(t, "tl-task", "tl-epic", DepParentChild, time.Date(2026, 2, 9, 10, 0, 0, 0, time.UTC)),
+	}))
+
+	setReadyGlobals(t, dir, false)
+	readyExplain = true
+	cmd := newTestCommand()
+	require.NoError(t, runReady(cmd, nil))
+	assert.Equal(t,
+		"tl-bug P1 Crash on save\ntl-epic P3 Epic\n"+
+			"Not ready:\n"+
+			"  tl-wait P0 Waiting: blocked by tl-task\n"+
+			"  tl-task P2 Write docs: blocked by tl-epic\n",
+		cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	setReadyGlobals(t, dir, true)
+	readyIncludeInProgress = true
+	readyLabel = ""
+	cmd = newTestCommand()
+	require.NoError(t, runReady(cmd, nil))
+	var rows []readyIssue
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &rows))
+	require.Len(t, rows, 3)
+	assert.Equal(t, "tl-mine", rows[0].ID)
+	assert.Equal(t, StatusInProgress, rows[0].Status)
+	assert.Equal(t, TypeBug, rows[1].IssueType)
+	assert.Equal(t, []string{"ui"}, rows[1].Labels)
+	assert.False(t, rows[1].CreatedAt.IsZero())
+
+	readyIncludeInProgress = false
+	readyExplain = true
+	cmd = newTestCommand()
+	require.NoError(t, runReady(cmd, nil))
+	var explained struct {
+		Ready    []readyIssue    `json:"ready"`
+		Excluded []excludedIssue `json:"excluded"`
+	}
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &explained))
+	require.Len(t, explained.Excluded, 2)
+	assert.Equal(t, "tl-task", explained.Excluded[1].ID)
+}
+
+func TestNotReadyReasonDeferredAndPinned(t *testing.T) {
+	now := time.Date(2026, 2, 9, 12, 0, 0, 0, time.UTC)
+	until := now.Add(24 * time.Hour)
+	issue := &Issue{ID: "tl-x", Status: StatusOpen, DeferUntil: &until, Pinned: true}
+	graph := &Graph{Tasks: map[string]*Issue{"tl-x": issue}}
+	assert.Equal(t, "deferred until 2026-02-10T12:00:00Z; pinned", notReadyReason(graph, issue, map[string]bool{}, now))
+}
+
+func TestNewReadyIssueParent(t *testing.T) {
+	issue := &Issue{ID: "tl-child", Dependencies: []*Dependency{
+		{IssueID: "tl-child", DependsOnID: "tl-other", Type: DepRelated},
+		{IssueID: "tl-child", DependsOnID: "tl-epic", Type: DepParentChild},
+	}}
+	assert.Equal(t, "tl-epic", newReadyIssue(issue).Parent)
+}
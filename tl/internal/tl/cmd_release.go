@@ -0,0 +1,70 @@
+// ABOUTME: Release command — hands a claimed task back to the ready queue.
+// ABOUTME: Implements `tl release <id> [--note]`, returning the task to open and clearing its assignee.
+
+package tl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	releaseAgent string
+	releaseNote  string
+)
+
+func init() {
+	releaseCmd.Args = cobra.ExactArgs(1)
+	releaseCmd.Flags().StringVar(&releaseAgent, "agent", resolveActor(), "Agent holding the claim")
+	releaseCmd.Flags().StringVar(&releaseNote, "note", "", "Why the task is being released (recorded in the release event)")
+	releaseCmd.RunE = runRelease
+}
+
+func runRelease(cmd *cobra.Command, args []string) error {
+	id := args[0]
+	agent := releaseAgent
+	if agent == "" {
+		agent = resolveActor()
+	}
+
+	opts := GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag}
+	dir, err := tlDir(opts)
+	if err != nil {
+		return err
+	}
+
+	var released Issue
+	err = mutate(dir, func(g *Graph) ([]Event, error) {
+		issue, ok := g.Tasks[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		if issue.Status != StatusInProgress {
+			return nil, conflictError("task %s is not in progress (status: %s)", id, issue.Status)
+		}
+		if issue.Assignee != "" && issue.Assignee != agent {
+			return nil, conflictError("task %s is claimed by %s, not %s (use tl claim --steal to take it over)", id, issue.Assignee, agent)
+		}
+
+		evt, err := newEvent(EventRelease, id, ReleaseEventData{Agent: agent, Note: strings.TrimSpace(releaseNote)})
+		if err != nil {
+			return nil, err
+		}
+		if err := g.applyEvent(evt); err != nil {
+			return nil, err
+		}
+		released = *issue
+		return []Event{evt}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		return printIssueJSON(cmd, &released)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Released %s\n", released.ID)
+	return nil
+}
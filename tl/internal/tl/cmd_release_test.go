@@ -0,0 +1,92 @@
+// ABOUTME: Tests tl release and tl claim --steal custody changes.
+// ABOUTME: Verifies assignee clearing, ownership checks, required steal reasons and the recorded handoff event.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedClaimedRepo has tl-held claimed by agent-1 with a lease.
+func seedClaimedRepo(t *testing.T) string {
+	t.Helper()
+	now := time.Now().UTC()
+	return seedCommandRepoWithEvents(t,
+		createIssueEvent(t, "tl-held", "Held", StatusOpen, 1, now.Add(-time.Hour)),
+		claimEventAt(t, "tl-held", "agent-1", "2h", now.Add(-time.Minute)),
+	)
+}
+
+func setReleaseGlobals(t *testing.T, dir, agent, note string) {
+	t.Helper()
+	setCommandGlobals(t, dir, false)
+	prevAgent, prevNote := releaseAgent, releaseNote
+	t.Cleanup(func() { releaseAgent, releaseNote = prevAgent, prevNote })
+	releaseAgent, releaseNote = agent, note
+}
+
+func TestReleaseReturnsTaskToOpen(t *testing.T) {
+	dir := seedClaimedRepo(t)
+
+	setReleaseGlobals(t, dir, "agent-2", "")
+	assert.ErrorContains(t, runRelease(newTestCommand(), []string{"tl-held"}), "claimed by agent-1, not agent-2")
+
+	setReleaseGlobals(t, dir, "agent-1", "stuck on credentials")
+	cmd := newTestCommand()
+	require.NoError(t, runRelease(cmd, []string{"tl-held"}))
+	assert.Equal(t, "Released tl-held\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	issue := graph.Tasks["tl-held"]
+	assert.Equal(t, StatusOpen, issue.Status)
+	assert.Empty(t, issue.Assignee)
+	assert.Nil(t, issue.Lease)
+
+	events, err := readEvents(filepath.Join(dir, eventsFileName))
+	require.NoError(t, err)
+	last := events[len(events)-1]
+	assert.Equal(t, EventRelease, last.Type)
+	var data ReleaseEventData
+	require.NoError(t, json.Unmarshal(last.Data, &data))
+	assert.Equal(t, ReleaseEventData{Agent: "agent-1", Note: "stuck on credentials"}, data)
+
+	assert.ErrorContains(t, runRelease(newTestCommand(), []string{"tl-held"}), "not in progress")
+}
+
+func TestClaimStealRecordsHandoff(t *testing.T) {
+	dir := seedClaimedRepo(t)
+	setClaimCommandGlobals(t, dir, false, "agent-2")
+
+	err := runClaim(newClaimCommand(t), []string{"tl-held"})
+	assert.ErrorContains(t, err, "use --steal --reason")
+
+	claimSteal = true
+	assert.ErrorContains(t, runClaim(newClaimCommand(t), []string{"tl-held"}), "--steal requires --reason")
+
+	claimReason = "agent-1 session crashed"
+	cmd := newClaimCommand(t)
+	require.NoError(t, runClaim(cmd, []string{"tl-held"}))
+	assert.Equal(t, "Handed off tl-held from agent-1 to agent-2: agent-1 session crashed\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "agent-2", graph.Tasks["tl-held"].Assignee)
+	assert.Equal(t, StatusInProgress, graph.Tasks["tl-held"].Status)
+	assert.Nil(t, graph.Tasks["tl-held"].Lease, "the stealer's claim has no lease unless it asks for one")
+
+	events, err := readEvents(filepath.Join(dir, eventsFileName))
+	require.NoError(t, err)
+	last := events[len(events)-1]
+	assert.Equal(t, EventHandoff, last.Type)
+	var data HandoffEventData
+	require.NoError(t, json.Unmarshal(last.Data, &data))
+	assert.Equal(t, HandoffEventData{From: "agent-1", To: "agent-2", Reason: "agent-1 session crashed"}, data)
+}
@@ -17,7 +17,7 @@ func init() {
 
 func runShow(cmd *cobra.Command, args []string) error {
 	if len(args) < 1 {
-		return fmt.Errorf("requires an issue ID argument")
+		return validationError("requires an issue ID argument")
 	}
 	id := args[0]
 
@@ -38,13 +38,13 @@ func runShow(cmd *cobra.Command, args []string) error {
 	}
 
 	if opts.JSON {
-		return printShowJSON(cmd, issue)
+		return printShowJSON(cmd, graph, issue)
 	}
-	return printShowText(cmd, issue)
+	return printShowText(cmd, graph, issue)
 }
 
-func printShowJSON(cmd *cobra.Command, issue *Issue) error {
-	data, err := json.Marshal(issue)
+func printShowJSON(cmd *cobra.Command, graph *Graph, issue *Issue) error {
+	data, err := json.Marshal(withProgress(graph, issue))
 	if err != nil {
 		return err
 	}
@@ -52,7 +52,7 @@ func printShowJSON(cmd *cobra.Command, issue *Issue) error {
 	return nil
 }
 
-func printShowText(cmd *cobra.Command, issue *Issue) error {
+func printShowText(cmd *cobra.Command, graph *Graph, issue *Issue) error {
 	w := cmd.OutOrStdout()
 	fmt.Fprintf(w, "id:           %s\n", issue.ID)
 	fmt.Fprintf(w, "title:        %s\n", issue.Title)
@@ -69,5 +69,20 @@ func printShowText(cmd *cobra.Command, issue *Issue) error {
 		depIDs = append(depIDs, dep.DependsOnID)
 	}
 	fmt.Fprintf(w, "dependencies: %s\n", strings.Join(depIDs, " "))
+
+	if issue.IssueType == TypeEpic {
+		progress := computeEpicProgress(graph, issue)
+		fmt.Fprintf(w, "progress:     %d/%d closed (%d%%) %s\n", progress.Closed, progress.Total, progress.Percent, formatStatusCounts(progress.ByStatus))
+	}
+	if issue.Notes != "" {
+		fmt.Fprintf(w, "notes:\n")
+		for _, line := range strings.Split(strings.TrimRight(issue.Notes, "\n"), "\n") {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+	if len(issue.Comments) > 0 {
+		fmt.Fprintf(w, "comments:\n")
+		writeCommentThread(w, issue, "  ")
+	}
 	return nil
 }
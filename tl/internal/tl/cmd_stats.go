@@ -1,22 +1,24 @@
 // ABOUTME: Stats command implementation for task status and blocked-set counts.
-// ABOUTME: Reports workflow counts in either compact text format or machine-readable JSON.
+// ABOUTME: Reports workflow counts and WIP limit usage in either compact text format or machine-readable JSON.
 
 package tl
 
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
 type statsOutput struct {
-	Open       int `json:"open"`
-	InProgress int `json:"in_progress"`
-	Blocked    int `json:"blocked"`
-	Closed     int `json:"closed"`
-	Deferred   int `json:"deferred"`
-	Total      int `json:"total"`
+	Open       int        `json:"open"`
+	InProgress int        `json:"in_progress"`
+	Blocked    int        `json:"blocked"`
+	Closed     int        `json:"closed"`
+	Deferred   int        `json:"deferred"`
+	Total      int        `json:"total"`
+	WIP        []wipUsage `json:"wip,omitempty"`
 }
 
 func init() {
@@ -34,7 +36,13 @@ func runStats(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return err
+	}
+
 	stats := computeStats(graph)
+	stats.WIP = computeWIPUsage(graph, cfg.WIP, time.Now())
 
 	if jsonOutput {
 		data, err := json.Marshal(stats)
@@ -54,6 +62,13 @@ func runStats(cmd *cobra.Command, args []string) error {
 		stats.Closed,
 		stats.Total,
 	)
+	for _, usage := range stats.WIP {
+		subject := usage.Scope
+		if usage.Key != "" {
+			subject += " " + usage.Key
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "WIP %s: %d/%d\n", subject, usage.Current, usage.Limit)
+	}
 	return nil
 }
 
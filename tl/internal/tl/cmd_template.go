@@ -0,0 +1,132 @@
+// ABOUTME: Template commands — instantiate a template as an epic subgraph, or capture an epic as a template.
+// ABOUTME: Implements `tl template apply <name> --var k=v` and `tl template extract <epic> [--name] [--force]`.
+
+package tl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	templateVars         []string
+	templateExtractName  string
+	templateExtractForce bool
+)
+
+func init() {
+	templateApplyCmd.Args = cobra.ExactArgs(1)
+	templateApplyCmd.Flags().StringArrayVar(&templateVars, "var", nil, "Template variable as name=value (repeatable)")
+	templateApplyCmd.RunE = runTemplateApply
+
+	templateExtractCmd.Args = cobra.ExactArgs(1)
+	templateExtractCmd.Flags().StringVar(&templateExtractName, "name", "", "Template name (default: derived from the epic title)")
+	templateExtractCmd.Flags().BoolVar(&templateExtractForce, "force", false, "Overwrite an existing template")
+	templateExtractCmd.RunE = runTemplateExtract
+}
+
+func runTemplateApply(cmd *cobra.Command, args []string) error {
+	supplied := make(map[string]string, len(templateVars))
+	for _, raw := range templateVars {
+		name, value, ok := strings.Cut(raw, "=")
+		if !ok || name == "" {
+			return validationError("invalid --var %q (want name=value)", raw)
+		}
+		supplied[name] = value
+	}
+
+	dir, err := tlDir(GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag})
+	if err != nil {
+		return err
+	}
+	tmpl, err := loadTemplate(dir, args[0])
+	if err != nil {
+		return err
+	}
+	inst, err := applyTemplate(dir, tmpl, supplied)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		data, err := json.Marshal(inst)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	w := cmd.OutOrStdout()
+	fmt.Fprintf(w, "Created %s: %s (%d tasks from template %s)\n", inst.Epic.ID, inst.Epic.Title, len(inst.Tasks), tmpl.Name)
+	for _, task := range inst.Tasks {
+		fmt.Fprintf(w, "  %s %s: %s\n", task.ID, task.Key, task.Title)
+	}
+	return nil
+}
+
+func runTemplateExtract(cmd *cobra.Command, args []string) error {
+	dir, err := tlDir(GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag})
+	if err != nil {
+		return err
+	}
+	events, err := readEvents(filepath.Join(dir, eventsFileName))
+	if err != nil {
+		return err
+	}
+	graph, err := replayEvents(events)
+	if err != nil {
+		return err
+	}
+	tmpl, err := extractTemplate(graph, events, args[0])
+	if err != nil {
+		return err
+	}
+
+	name := templateExtractName
+	if name == "" {
+		name = templateKey(tmpl.Title)
+	}
+	if !templateKeyPattern.MatchString(name) {
+		return validationError("template name %q must be lowercase letters, digits and dashes", name)
+	}
+	path := filepath.Join(templatesDir(dir), name+".yaml")
+	if _, err := os.Stat(path); err == nil && !templateExtractForce {
+		return conflictError("%s already exists (use --force to overwrite)", path)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	data, err := yaml.Marshal(tmpl)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(templatesDir(dir), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		out, err := json.Marshal(struct {
+			Name  string `json:"name"`
+			Path  string `json:"path"`
+			Tasks int    `json:"tasks"`
+		}{name, path, len(tmpl.Tasks)})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote template %s to %s (%d tasks)\n", name, path, len(tmpl.Tasks))
+	return nil
+}
@@ -0,0 +1,49 @@
+// ABOUTME: Tick command — creates the due instances of recurring schedules.
+// ABOUTME: Implements `tl tick`; idempotent, so it is safe to run from cron jobs or git hooks.
+
+package tl
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	tickCmd.Args = cobra.NoArgs
+	tickCmd.RunE = runTick
+}
+
+func runTick(cmd *cobra.Command, args []string) error {
+	dir, err := tlDir(GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag})
+	if err != nil {
+		return err
+	}
+
+	results, err := tick(dir, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		data, err := json.Marshal(results)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	w := cmd.OutOrStdout()
+	if len(results) == 0 {
+		fmt.Fprintln(w, "Nothing due")
+		return nil
+	}
+	for _, result := range results {
+		fmt.Fprintf(w, "Created %s: %s (%s, due %s)\n", result.Issue.ID, strings.TrimSpace(result.Issue.Title), result.Schedule, result.Occurrence.Format(time.RFC3339))
+	}
+	return nil
+}
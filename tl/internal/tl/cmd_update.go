@@ -1,11 +1,12 @@
 // ABOUTME: Update command — modifies task fields including status, title, description, priority, and assignee.
-// ABOUTME: Implements `tl update <id>` with selective field updates under write lock.
+// ABOUTME: Implements `tl update <id>` with selective field updates under write lock; moving to in_progress respects WIP limits and closing follows tl close's epic rules.
 
 package tl
 
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -17,13 +18,15 @@ func init() {
 	updateCmd.Flags().Int("priority", -1, "New priority (0-5)")
 	updateCmd.Flags().String("assignee", "", "New assignee")
 	updateCmd.Flags().String("type", "", "New issue type")
+	updateCmd.Flags().Int("estimate", 0, "New estimated effort in minutes")
+	updateCmd.Flags().String("spec", "", "Linked spec (a path in the repo or an openspec spec/change name)")
 
 	updateCmd.RunE = runUpdate
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
 	if len(args) < 1 {
-		return fmt.Errorf("usage: tl update <id>")
+		return validationError("usage: tl update <id>")
 	}
 	id := args[0]
 
@@ -37,6 +40,9 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 
 	if cmd.Flags().Changed("status") {
 		val, _ := cmd.Flags().GetString("status")
+		if !Status(val).IsValid() {
+			return validationError("unknown status: %s", val)
+		}
 		raw, _ := json.Marshal(val)
 		fields["status"] = raw
 	}
@@ -65,12 +71,29 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		raw, _ := json.Marshal(val)
 		fields["issue_type"] = raw
 	}
+	if cmd.Flags().Changed("estimate") {
+		val, _ := cmd.Flags().GetInt("estimate")
+		raw, _ := json.Marshal(val)
+		fields[estimateMetadataKey] = raw
+	}
+
+	if cmd.Flags().Changed("spec") {
+		val, _ := cmd.Flags().GetString("spec")
+		raw, _ := json.Marshal(val)
+		fields["spec_id"] = raw
+	}
 
 	if len(fields) == 0 {
-		return fmt.Errorf("no fields to update")
+		return validationError("no fields to update")
+	}
+
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return err
 	}
 
 	var updatedIssue Issue
+	var autoClosed []string
 	err = mutate(dir, func(g *Graph) ([]Event, error) {
 		issue, ok := g.Tasks[id]
 		if !ok {
@@ -78,54 +101,65 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		}
 
 		// Validate status transition if status is being changed
+		newStatus := issue.Status
 		if raw, ok := fields["status"]; ok {
-			var newStatus Status
 			if err := json.Unmarshal(raw, &newStatus); err != nil {
 				return nil, err
 			}
-			if err := validateTransition(issue.Status, newStatus); err != nil {
+			from := issue.Status
+			if deferLapsed(issue, time.Now()) {
+				from = StatusOpen
+			}
+			if err := validateTransition(from, newStatus); err != nil {
+				return nil, err
+			}
+		}
+		assignee := issue.Assignee
+		if raw, ok := fields["assignee"]; ok {
+			_ = json.Unmarshal(raw, &assignee)
+		}
+		// Starting work, or handing in-progress work to someone else, takes a WIP slot.
+		if newStatus == StatusInProgress && (issue.Status != StatusInProgress || assignee != issue.Assignee) {
+			if err := checkWIP(g, cfg.WIP, issue, assignee, time.Now()); err != nil {
 				return nil, err
 			}
 		}
 
-		evt, err := newEvent(EventUpdate, id, UpdateEventData{Fields: fields})
-		if err != nil {
-			return nil, err
+		// Closing goes through the shared close path so the epic guard and
+		// auto-close apply; the update carries the remaining fields.
+		closing := newStatus == StatusClosed && issue.Status != StatusClosed
+		updates := fields
+		if closing {
+			updates = make(map[string]json.RawMessage, len(fields))
+			for name, raw := range fields {
+				if name != "status" {
+					updates[name] = raw
+				}
+			}
 		}
 
-		// Apply field changes to the in-memory issue for post-mutate capture
-		for field, value := range fields {
-			switch field {
-			case "status":
-				var v Status
-				_ = json.Unmarshal(value, &v)
-				issue.Status = v
-			case "title":
-				var v string
-				_ = json.Unmarshal(value, &v)
-				issue.Title = v
-			case "description":
-				var v string
-				_ = json.Unmarshal(value, &v)
-				issue.Description = v
-			case "priority":
-				var v int
-				_ = json.Unmarshal(value, &v)
-				issue.Priority = v
-			case "assignee":
-				var v string
-				_ = json.Unmarshal(value, &v)
-				issue.Assignee = v
-			case "issue_type":
-				var v IssueType
-				_ = json.Unmarshal(value, &v)
-				issue.IssueType = v
+		var events []Event
+		if len(updates) > 0 {
+			evt, err := newEvent(EventUpdate, id, UpdateEventData{Fields: updates})
+			if err != nil {
+				return nil, err
 			}
+			if err := g.applyEvent(evt); err != nil {
+				return nil, err
+			}
+			events = append(events, evt)
+		}
+		if closing {
+			result, err := closeIssue(g, cfg, issue, "", false)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, result.Events...)
+			autoClosed = result.AutoClosed
 		}
-		issue.UpdatedAt = evt.Timestamp
 		updatedIssue = *issue
 
-		return []Event{evt}, nil
+		return events, nil
 	})
 	if err != nil {
 		return err
@@ -139,6 +173,9 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		fmt.Fprintln(cmd.OutOrStdout(), string(out))
 	} else {
 		fmt.Fprintf(cmd.OutOrStdout(), "Updated %s\n", id)
+		for _, epicID := range autoClosed {
+			fmt.Fprintf(cmd.OutOrStdout(), "Auto-closed epic %s\n", epicID)
+		}
 	}
 
 	return nil
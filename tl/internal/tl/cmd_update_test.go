@@ -4,7 +4,9 @@
 package tl
 
 import (
+	"bytes"
 	"encoding/json"
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
@@ -153,3 +155,51 @@ func TestUpdateNotFound(t *testing.T) {
 	})
 	assert.ErrorIs(t, err, ErrNotFound)
 }
+
+func TestUpdateJSONReflectsAppliedFields(t *testing.T) {
+	dir := seedIssue(t, "tl-u006", "Spec Update", StatusOpen)
+	setCommandGlobals(t, dir, true)
+
+	cmd := newTestCommand()
+	cmd.Flags().String("spec", "", "")
+	cmd.Flags().Int("estimate", 0, "")
+	require.NoError(t, cmd.Flags().Set("spec", "docs/y.md"))
+	require.NoError(t, cmd.Flags().Set("estimate", "45"))
+	require.NoError(t, runUpdate(cmd, []string{"tl-u006"}))
+
+	var printed Issue
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &printed))
+	assert.Equal(t, "docs/y.md", printed.SpecID)
+	assert.NotContains(t, printed.Metadata, "spec_id")
+
+	g, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.Equal(t, printed.SpecID, g.Tasks["tl-u006"].SpecID, "the printed issue matches tl show")
+	assert.Equal(t, 45, issueEstimate(&printed))
+}
+
+func TestUpdateUnknownStatusIsValidationError(t *testing.T) {
+	dir := seedIssue(t, "tl-u007", "Bogus Status", StatusOpen)
+	setCommandGlobals(t, dir, true)
+
+	cmd := newTestCommand()
+	cmd.Flags().String("status", "", "")
+	require.NoError(t, cmd.Flags().Set("status", "bogus"))
+	err := runUpdate(cmd, []string{"tl-u007"})
+	require.ErrorIs(t, err, ErrValidation)
+	assert.Equal(t, exitValidation, exitCode(err))
+}
+
+func TestUpdateMalformedConfigIsValidationError(t *testing.T) {
+	dir := seedIssue(t, "tl-u008", "Bad Config", StatusOpen)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, configFileName), []byte("wip: [unterminated\n"), 0644))
+	setCommandGlobals(t, dir, true)
+
+	cmd := newTestCommand()
+	cmd.Flags().String("title", "", "")
+	require.NoError(t, cmd.Flags().Set("title", "New"))
+	err := runUpdate(cmd, []string{"tl-u008"})
+	require.ErrorIs(t, err, ErrValidation)
+	assert.Equal(t, exitValidation, exitCode(err))
+	assert.Contains(t, err.Error(), configFileName)
+}
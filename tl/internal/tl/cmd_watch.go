@@ -0,0 +1,68 @@
+// ABOUTME: Watch command — streams new events from the log as JSON lines until interrupted.
+// ABOUTME: Implements `tl watch` with --id/--type/--label/--actor filters and became_ready/became_blocked notifications.
+
+package tl
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchID       string
+	watchType     string
+	watchLabel    string
+	watchActor    string
+	watchIssue    bool
+	watchInterval time.Duration
+	watchPoll     bool
+)
+
+func init() {
+	watchCmd.Args = cobra.NoArgs
+	watchCmd.Flags().StringVar(&watchID, "id", "", "Only report records about this task")
+	watchCmd.Flags().StringVar(&watchType, "type", "", "Only report this event type or notification kind (became_ready, became_blocked)")
+	watchCmd.Flags().StringVar(&watchLabel, "label", "", "Only report tasks carrying this label")
+	watchCmd.Flags().StringVar(&watchActor, "actor", "", "Only report events written by this actor")
+	watchCmd.Flags().BoolVar(&watchIssue, "issue", false, "Include the task's state after each record")
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", defaultWatchInterval, "Polling interval; also bounds how late lease expiry and deferral wake-ups are reported")
+	watchCmd.Flags().BoolVar(&watchPoll, "poll", false, "Poll the log instead of using inotify")
+	watchCmd.RunE = runWatch
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	dir, err := tlDir(GlobalOptions{JSON: jsonOutput, Dir: tlDirFlag})
+	if err != nil {
+		return err
+	}
+	if watchInterval <= 0 {
+		return validationError("--interval must be positive")
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	opts := watchOptions{
+		ID:        watchID,
+		Type:      watchType,
+		Label:     watchLabel,
+		Actor:     watchActor,
+		WithIssue: watchIssue,
+		Interval:  watchInterval,
+		Poll:      watchPoll,
+	}
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	return watchEvents(ctx, dir, opts, func(rec watchRecord) error {
+		return encoder.Encode(rec)
+	})
+}
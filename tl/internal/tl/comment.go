@@ -0,0 +1,62 @@
+// ABOUTME: Comment threads on issues: numbering, reply lookup and threaded text rendering.
+// ABOUTME: Comments are append-only comment events; replies point at an earlier comment on the same issue.
+
+package tl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// nextCommentID returns the number for a new comment on issue. Numbers are
+// assigned under the write lock, so they are unique within the issue.
+func nextCommentID(issue *Issue) int {
+	next := 1
+	for _, comment := range issue.Comments {
+		if comment.ID >= next {
+			next = comment.ID + 1
+		}
+	}
+	return next
+}
+
+func findComment(issue *Issue, id int) *Comment {
+	for _, comment := range issue.Comments {
+		if comment.ID == id {
+			return comment
+		}
+	}
+	return nil
+}
+
+// writeCommentThread renders issue's comments as a tree: replies are indented
+// under the comment they answer, each level in creation order. A reply whose
+// target does not resolve to an earlier comment is shown at the top level.
+func writeCommentThread(w io.Writer, issue *Issue, indent string) {
+	children := make(map[int][]*Comment)
+	var roots []*Comment
+	for _, comment := range issue.Comments {
+		if comment.ReplyTo > 0 && comment.ReplyTo < comment.ID && findComment(issue, comment.ReplyTo) != nil {
+			children[comment.ReplyTo] = append(children[comment.ReplyTo], comment)
+			continue
+		}
+		roots = append(roots, comment)
+	}
+
+	var write func(comment *Comment, depth int)
+	write = func(comment *Comment, depth int) {
+		prefix := indent + strings.Repeat("  ", depth)
+		fmt.Fprintf(w, "%s#%d %s %s\n", prefix, comment.ID, comment.Author, comment.CreatedAt.UTC().Format(time.RFC3339))
+		for _, line := range strings.Split(strings.TrimRight(comment.Text, "\n"), "\n") {
+			fmt.Fprintf(w, "%s  %s\n", prefix, line)
+		}
+		for _, reply := range children[comment.ID] {
+			write(reply, depth+1)
+		}
+	}
+	for _, comment := range roots {
+		write(comment, 0)
+	}
+}
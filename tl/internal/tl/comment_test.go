@@ -0,0 +1,115 @@
+// ABOUTME: Tests comment threads: tl comment add/list, replies, stdin input and tl show rendering.
+// ABOUTME: Also covers the beads round trip — comments export in beads shape and re-import without duplicates.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setCommentGlobals(t *testing.T, dir string, json bool, actor string) {
+	t.Helper()
+	setCommandGlobals(t, dir, json)
+	t.Setenv("TL_ACTOR", actor)
+	prev := commentReplyTo
+	t.Cleanup(func() { commentReplyTo = prev })
+	commentReplyTo = 0
+}
+
+func TestCommentAddReplyAndList(t *testing.T) {
+	dir := seedCommandRepoWithEvents(t, createIssueEvent(t, "tl-a", "Task", StatusOpen, 1, time.Now().UTC()))
+	setCommentGlobals(t, dir, false, "alice")
+
+	cmd := newTestCommand()
+	require.NoError(t, runCommentAdd(cmd, []string{"tl-a", "Should we cache this?"}))
+	assert.Equal(t, "Added comment #1 to tl-a\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	t.Setenv("TL_ACTOR", "bob")
+	commentReplyTo = 1
+	cmd = newTestCommand()
+	cmd.SetIn(strings.NewReader("Yes.\nThe lookup is hot.\n"))
+	require.NoError(t, runCommentAdd(cmd, []string{"tl-a"}))
+	assert.Equal(t, "Added comment #2 to tl-a (reply to #1)\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	commentReplyTo = 9
+	assert.ErrorIs(t, runCommentAdd(newTestCommand(), []string{"tl-a", "orphan"}), ErrNotFound)
+	commentReplyTo = 0
+	assert.ErrorIs(t, runCommentAdd(newTestCommand(), []string{"tl-a", "  "}), ErrValidation)
+	assert.ErrorIs(t, runCommentAdd(newTestCommand(), []string{"tl-nope", "hi"}), ErrNotFound)
+
+	require.NoError(t, runCommentAdd(newTestCommand(), []string{"tl-a", "Second topic"}))
+
+	cmd = newTestCommand()
+	require.NoError(t, runCommentList(cmd, []string{"tl-a"}))
+	lines := strings.Split(strings.TrimRight(cmd.OutOrStdout().(*bytes.Buffer).String(), "\n"), "\n")
+	require.Len(t, lines, 7)
+	assert.True(t, strings.HasPrefix(lines[0], "#1 alice "))
+	assert.Equal(t, "  Should we cache this?", lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "  #2 bob "))
+	assert.Equal(t, []string{"    Yes.", "    The lookup is hot."}, lines[3:5])
+	assert.True(t, strings.HasPrefix(lines[5], "#3 bob "))
+
+	cmd = newTestCommand()
+	require.NoError(t, runShow(cmd, []string{"tl-a"}))
+	assert.Contains(t, cmd.OutOrStdout().(*bytes.Buffer).String(), "comments:\n  #1 alice ")
+
+	setCommentGlobals(t, dir, true, "bob")
+	cmd = newTestCommand()
+	require.NoError(t, runCommentList(cmd, []string{"tl-a"}))
+	var comments []Comment
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &comments))
+	require.Len(t, comments, 3)
+	assert.Equal(t, 1, comments[1].ReplyTo)
+	assert.Equal(t, "tl-a", comments[1].IssueID)
+}
+
+func TestCommentsRoundTripThroughBeads(t *testing.T) {
+	ts := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	comment := func(id, replyTo int, author, text string, at time.Time) Event {
+		data, err := json.Marshal(CommentEventData{CommentID: id, Text: text, ReplyTo: replyTo})
+		require.NoError(t, err)
+		return Event{Type: EventComment, ID: "tl-a", Timestamp: at, Actor: author, Data: data}
+	}
+	source := seedCommandRepoWithEvents(t,
+		createIssueEvent(t, "tl-a", "Task", StatusOpen, 1, ts),
+		comment(1, 0, "alice", "Question", ts.Add(time.Minute)),
+		comment(2, 1, "bob", "Answer", ts.Add(2*time.Minute)),
+	)
+	graph, err := loadGraph(source)
+	require.NoError(t, err)
+
+	line, err := issueToBeadsJSON(graph.Tasks["tl-a"])
+	require.NoError(t, err)
+	var beads struct {
+		Comments []map[string]any `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal(line, &beads))
+	require.Len(t, beads.Comments, 2)
+	assert.Equal(t, map[string]any{"id": 2.0, "issue_id": "tl-a", "author": "bob", "text": "Answer", "created_at": "2026-03-02T09:02:00Z", "reply_to": 1.0}, beads.Comments[1])
+
+	from := filepath.Join(t.TempDir(), "issues.jsonl")
+	require.NoError(t, os.WriteFile(from, append(line, '\n'), 0644))
+	root := t.TempDir()
+	require.NoError(t, initDir(root))
+	setImportGlobals(t, root, from, false)
+	for range 2 {
+		require.NoError(t, runImport(newTestCommand(), nil))
+	}
+
+	imported, err := loadGraph(filepath.Join(root, tlDirName))
+	require.NoError(t, err)
+	issue := imported.Tasks["tl-a"]
+	require.Len(t, issue.Comments, 2, "re-import must not duplicate comments")
+	assert.Equal(t, "bob", issue.Comments[1].Author)
+	assert.Equal(t, 1, issue.Comments[1].ReplyTo)
+	assert.NotContains(t, issue.Metadata, "comments")
+}
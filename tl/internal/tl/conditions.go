@@ -0,0 +1,178 @@
+// ABOUTME: Interprets Dependency.Metadata for conditional-blocks and waits-for edges.
+// ABOUTME: Decides whether a single dependency edge currently holds its issue back, and explains why.
+
+package tl
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Waits-for gate kinds, matching the beads fan-in gate vocabulary.
+const (
+	GateAllChildren = "all-children"
+	GateAnyChildren = "any-children"
+)
+
+// DepCondition is the interpreted form of Dependency.Metadata.
+// conditional-blocks edges use CloseReason/Label; waits-for edges use Gate.
+type DepCondition struct {
+	CloseReason string `json:"close_reason,omitempty"`
+	Label       string `json:"label,omitempty"`
+	Gate        string `json:"gate,omitempty"`
+}
+
+// IsZero reports whether the condition carries no constraints.
+func (c DepCondition) IsZero() bool {
+	return c.CloseReason == "" && c.Label == "" && c.Gate == ""
+}
+
+// parseDepCondition decodes edge metadata. Metadata that is not a condition
+// object (e.g. foreign beads data) yields a zero condition rather than an error.
+func parseDepCondition(raw json.RawMessage) DepCondition {
+	var cond DepCondition
+	if len(raw) == 0 {
+		return cond
+	}
+	if err := json.Unmarshal(raw, &cond); err != nil {
+		return DepCondition{}
+	}
+	return cond
+}
+
+// validateDepCondition checks that a condition fits the dependency type it is attached to.
+func validateDepCondition(depType DependencyType, cond DepCondition) error {
+	if cond.IsZero() {
+		return nil
+	}
+	switch depType {
+	case DepConditionalBlocks:
+		if cond.Gate != "" {
+			return validationError("gate conditions apply only to %s dependencies", DepWaitsFor)
+		}
+	case DepWaitsFor:
+		if cond.CloseReason != "" || cond.Label != "" {
+			return validationError("close-reason and label conditions apply only to %s dependencies", DepConditionalBlocks)
+		}
+		if cond.Gate != GateAllChildren && cond.Gate != GateAnyChildren {
+			return validationError("unknown gate %q (want %s or %s)", cond.Gate, GateAllChildren, GateAnyChildren)
+		}
+	default:
+		return validationError("conditions apply only to %s and %s dependencies", DepConditionalBlocks, DepWaitsFor)
+	}
+	return nil
+}
+
+// hasCondition reports whether the edge carries interpreted metadata.
+func (d *Dependency) hasCondition() bool {
+	if d.Type != DepConditionalBlocks && d.Type != DepWaitsFor {
+		return false
+	}
+	return !parseDepCondition(d.Metadata).IsZero()
+}
+
+// dependencyBlocks reports whether dep currently keeps its issue out of the
+// ready queue. The returned string explains conditional or gated edges and is
+// empty for plain status-based blocking.
+func dependencyBlocks(graph *Graph, dep *Dependency, blockedSet map[string]bool) (bool, string) {
+	if dep == nil || !dep.Type.AffectsReadyWork() {
+		return false, ""
+	}
+	target, ok := graph.Tasks[dep.DependsOnID]
+	if !ok {
+		ref, remote := graph.remote[dep.DependsOnID]
+		if !remote {
+			return false, ""
+		}
+		if ref.Issue == nil {
+			// A target we cannot see might still be open; hold the issue back.
+			return true, fmt.Sprintf("%s status unknown: %v", dep.DependsOnID, ref.Err)
+		}
+		target = ref.Issue
+	}
+
+	cond := parseDepCondition(dep.Metadata)
+	switch {
+	case dep.Type == DepConditionalBlocks && !cond.IsZero():
+		return conditionalBlockActive(target, cond)
+	case dep.Type == DepWaitsFor && cond.Gate != "":
+		return waitsForGateActive(graph, target, cond.Gate)
+	}
+
+	if dep.Type == DepParentChild && target.IssueType == TypeEpic {
+		// An epic is the container for its children's work, not a
+		// prerequisite: its children inherit blocking only while the epic
+		// itself is blocked. Any other open parent holds its children back.
+		return blockedSet[dep.DependsOnID], ""
+	}
+	if issueStatusBlocksReady(target.Status) {
+		return true, ""
+	}
+	if dep.Type == DepParentChild && blockedSet[dep.DependsOnID] {
+		return true, ""
+	}
+	return false, ""
+}
+
+// conditionalBlockActive blocks only while every condition set on the edge holds.
+func conditionalBlockActive(target *Issue, cond DepCondition) (bool, string) {
+	explanation := ""
+	if cond.CloseReason != "" {
+		if target.Status != StatusClosed || target.CloseReason != cond.CloseReason {
+			return false, ""
+		}
+		explanation = fmt.Sprintf("%s closed with reason %q", target.ID, cond.CloseReason)
+	}
+	if cond.Label != "" {
+		if !hasLabel(target, cond.Label) {
+			return false, ""
+		}
+		if explanation != "" {
+			explanation += " and "
+		} else {
+			explanation = target.ID + " "
+		}
+		explanation += fmt.Sprintf("has label %q", cond.Label)
+	}
+	return true, explanation
+}
+
+// waitsForGateActive evaluates a fan-in gate over the target's parent-child children.
+// A target without children falls back to ordinary status-based blocking.
+func waitsForGateActive(graph *Graph, target *Issue, gate string) (bool, string) {
+	children := childIDs(graph, target.ID)
+	if len(children) == 0 {
+		if issueStatusBlocksReady(target.Status) {
+			return true, fmt.Sprintf("%s has no children and is %s", target.ID, target.Status)
+		}
+		return false, ""
+	}
+
+	closed := 0
+	for _, id := range children {
+		if graph.Tasks[id].Status == StatusClosed {
+			closed++
+		}
+	}
+
+	switch gate {
+	case GateAllChildren:
+		if closed < len(children) {
+			return true, fmt.Sprintf("waiting for all children of %s (%d of %d closed)", target.ID, closed, len(children))
+		}
+	case GateAnyChildren:
+		if closed == 0 {
+			return true, fmt.Sprintf("waiting for any child of %s (0 of %d closed)", target.ID, len(children))
+		}
+	}
+	return false, ""
+}
+
+func hasLabel(issue *Issue, label string) bool {
+	for _, l := range issue.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+// ABOUTME: Tests conditional-blocks and waits-for edge semantics in blocked-set computation.
+// ABOUTME: Covers close-reason and label conditions, fan-in gates, and edge retention on close.
+
+package tl
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func conditionMetadata(t *testing.T, cond DepCondition) json.RawMessage {
+	t.Helper()
+	raw, err := json.Marshal(cond)
+	require.NoError(t, err)
+	return raw
+}
+
+func conditionalDepAddEvent(t *testing.T, issueID, dependsOnID string, depType DependencyType, cond DepCondition, ts time.Time) Event {
+	t.Helper()
+	data, err := json.Marshal(DepAddEventData{DependsOnID: dependsOnID, DepType: string(depType), Metadata: conditionMetadata(t, cond)})
+	require.NoError(t, err)
+	return Event{Type: EventDepAdd, ID: issueID, Timestamp: ts, Actor: "test", Data: data}
+}
+
+func TestConditionalBlocksOnCloseReason(t *testing.T) {
+	ts := time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC)
+	cond := DepCondition{CloseReason: "failed"}
+	dir := seedCommandRepoWithEvents(
+		t,
+		createIssueEvent(t, "tl-tests", "Run tests", StatusOpen, 0, ts),
+		createIssueEvent(t, "tl-deploy", "Deploy", StatusOpen, 0, ts.Add(time.Minute)),
+		conditionalDepAddEvent(t, "tl-deploy", "tl-tests", DepConditionalBlocks, cond, ts.Add(2*time.Minute)),
+	)
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.False(t, computeBlockedSet(graph)["tl-deploy"], "open blocker does not satisfy closed-with-reason condition")
+
+	require.NoError(t, appendEventsToFile(filepath.Join(dir, eventsFileName), []Event{
+		closeIssueEvent(t, "tl-tests", "failed", ts.Add(3*time.Minute)),
+	}))
+	graph, err = loadGraph(dir)
+	require.NoError(t, err)
+	require.Len(t, graph.Tasks["tl-deploy"].Dependencies, 1, "conditional edge survives blocker close")
+	assert.True(t, computeBlockedSet(graph)["tl-deploy"])
+
+	active, reason := dependencyBlocks(graph, graph.Tasks["tl-deploy"].Dependencies[0], nil)
+	assert.True(t, active)
+	assert.Equal(t, `tl-tests closed with reason "failed"`, reason)
+}
+
+func TestConditionalBlocksUnconditionalEdgeClearedOnClose(t *testing.T) {
+	ts := time.Date(2026, 2, 3, 10, 0, 0, 0, time.UTC)
+	dir := seedCommandRepoWithEvents(
+		t,
+		createIssueEvent(t, "tl-a", "A", StatusOpen, 0, ts),
+		createIssueEvent(t, "tl-b", "B", StatusOpen, 0, ts.Add(time.Minute)),
+		depAddEvent(t, "tl-b", "tl-a", DepConditionalBlocks, ts.Add(2*time.Minute)),
+		closeIssueEvent(t, "tl-a", "failed", ts.Add(3*time.Minute)),
+	)
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.Empty(t, graph.Tasks["tl-b"].Dependencies)
+	assert.Empty(t, graph.RDeps["tl-a"])
+}
+
+func TestKeepsConditionalEdgeSkipsNilDependencies(t *testing.T) {
+	conditional := &Dependency{IssueID: "tl-b", DependsOnID: "tl-a", Type: DepConditionalBlocks, Metadata: json.RawMessage(`{"close_reason":"failed"}`)}
+	issue := &Issue{ID: "tl-b", Dependencies: []*Dependency{nil, conditional, {IssueID: "tl-b", DependsOnID: "tl-a", Type: DepBlocks}}}
+
+	assert.True(t, keepsConditionalEdge(issue, "tl-a"))
+	assert.Equal(t, []*Dependency{conditional}, issue.Dependencies)
+}
+
+func TestConditionalBlocksOnLabel(t *testing.T) {
+	now := time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)
+	graph := &Graph{
+		Tasks: map[string]*Issue{
+			"tl-a": {ID: "tl-a", Status: StatusClosed, Labels: []string{"needs-review"}, CreatedAt: now},
+			"tl-b": {
+				ID: "tl-b", Status: StatusOpen, CreatedAt: now,
+				Dependencies: []*Dependency{{
+					IssueID: "tl-b", DependsOnID: "tl-a", Type: DepConditionalBlocks,
+					Metadata: conditionMetadata(t, DepCondition{Label: "needs-review"}),
+				}},
+			},
+		},
+	}
+
+	assert.True(t, computeBlockedSet(graph)["tl-b"])
+	graph.Tasks["tl-a"].Labels = nil
+	assert.False(t, computeBlockedSet(graph)["tl-b"])
+}
+
+func TestWaitsForGates(t *testing.T) {
+	now := time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)
+	newGraph := func(gate string, childStatuses ...Status) *Graph {
+		graph := &Graph{
+			Tasks: map[string]*Issue{
+				"tl-spawner": {ID: "tl-spawner", Status: StatusOpen, CreatedAt: now},
+				"tl-join": {
+					ID: "tl-join", Status: StatusOpen, CreatedAt: now,
+					Dependencies: []*Dependency{{
+						IssueID: "tl-join", DependsOnID: "tl-spawner", Type: DepWaitsFor,
+						Metadata: conditionMetadata(t, DepCondition{Gate: gate}),
+					}},
+				},
+			},
+			RDeps: map[string][]string{},
+		}
+		for i, status := range childStatuses {
+			id := "tl-child-" + string(rune('a'+i))
+			graph.Tasks[id] = &Issue{
+				ID: id, Status: status, CreatedAt: now,
+				Dependencies: []*Dependency{{IssueID: id, DependsOnID: "tl-spawner", Type: DepParentChild}},
+			}
+			graph.RDeps["tl-spawner"] = append(graph.RDeps["tl-spawner"], id)
+		}
+		return graph
+	}
+
+	assert.True(t, computeBlockedSet(newGraph(GateAllChildren, StatusClosed, StatusOpen))["tl-join"])
+	assert.False(t, computeBlockedSet(newGraph(GateAllChildren, StatusClosed, StatusClosed))["tl-join"])
+	assert.True(t, computeBlockedSet(newGraph(GateAnyChildren, StatusOpen, StatusOpen))["tl-join"])
+	assert.False(t, computeBlockedSet(newGraph(GateAnyChildren, StatusClosed, StatusOpen))["tl-join"])
+	assert.True(t, computeBlockedSet(newGraph(GateAnyChildren))["tl-join"], "no children falls back to target status")
+
+	graph := newGraph(GateAllChildren, StatusClosed, StatusOpen)
+	_, reason := dependencyBlocks(graph, graph.Tasks["tl-join"].Dependencies[0], nil)
+	assert.Equal(t, "waiting for all children of tl-spawner (1 of 2 closed)", reason)
+}
+
+func TestValidateDepCondition(t *testing.T) {
+	assert.NoError(t, validateDepCondition(DepBlocks, DepCondition{}))
+	assert.NoError(t, validateDepCondition(DepConditionalBlocks, DepCondition{CloseReason: "failed", Label: "x"}))
+	assert.NoError(t, validateDepCondition(DepWaitsFor, DepCondition{Gate: GateAnyChildren}))
+	assert.Error(t, validateDepCondition(DepWaitsFor, DepCondition{Gate: "most-children"}))
+	assert.Error(t, validateDepCondition(DepWaitsFor, DepCondition{Label: "x"}))
+	assert.Error(t, validateDepCondition(DepConditionalBlocks, DepCondition{Gate: GateAllChildren}))
+	assert.Error(t, validateDepCondition(DepBlocks, DepCondition{Label: "x"}))
+}
@@ -0,0 +1,66 @@
+// ABOUTME: Repository configuration loaded from .tl/config.yaml.
+// ABOUTME: A missing file yields defaults so every setting is opt-in.
+
+package tl
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const configFileName = "config.yaml"
+
+// Config holds optional repository-level behavior settings.
+type Config struct {
+	Epics EpicConfig  `yaml:"epics"`
+	Ready ReadyConfig `yaml:"ready"`
+	WIP   WIPConfig   `yaml:"wip"`
+	// Remotes maps an alias used in qualified dependency targets
+	// ("api:tl-a3f8") to another repository. Relative paths resolve
+	// against this repository's root.
+	Remotes map[string]string `yaml:"remotes"`
+}
+
+// EpicConfig controls epic rollup behavior.
+type EpicConfig struct {
+	// AutoClose closes an epic once its last open descendant closes.
+	AutoClose bool `yaml:"auto_close"`
+}
+
+// ReadyConfig controls ready-queue ordering.
+type ReadyConfig struct {
+	// Strategy is one of strict (default), aging, critical-path-first or
+	// downstream-unblock-count-first.
+	Strategy string `yaml:"strategy"`
+	// AgingDays is how long a task waits to gain one priority level under
+	// the aging strategy (default 7).
+	AgingDays float64 `yaml:"aging_days"`
+}
+
+// WIPConfig caps how many tasks may be in_progress at once. Zero means
+// unlimited.
+type WIPConfig struct {
+	Global      int `yaml:"global"`
+	PerAssignee int `yaml:"per_assignee"`
+	// Labels caps in_progress tasks carrying a label, e.g. {"area:db": 2}.
+	Labels map[string]int `yaml:"labels"`
+}
+
+func loadConfig(dir string) (Config, error) {
+	var cfg Config
+	path := filepath.Join(dir, configFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, validationError("%s: %w", path, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,356 @@
+// ABOUTME: Context bundle for `tl context`: everything an agent needs to start on one task, in one document.
+// ABOUTME: Gathers the task, blockers, parent epic, spec, notes, related tasks and comments, trimmed by priority to a byte budget.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Section names, listed from most to least important. Trimming works from the
+// end of this list; the task section is only ever shortened, never dropped.
+const (
+	contextTask     = "task"
+	contextBlockers = "blockers"
+	contextParent   = "parent"
+	contextSpec     = "spec"
+	contextNotes    = "notes"
+	contextRelated  = "related"
+	contextComments = "comments"
+)
+
+var contextPriority = []string{contextTask, contextBlockers, contextParent, contextSpec, contextNotes, contextRelated, contextComments}
+
+const contextTruncatedMarker = "\n[truncated]"
+
+// contextMinBody is the shortest a trimmed section may get; below that the
+// section is dropped instead, since a stub tells an agent very little.
+const contextMinBody = 64
+
+// contextSection is one Markdown block of the bundle.
+type contextSection struct {
+	Name    string `json:"name"`
+	Heading string `json:"heading"`
+	Body    string `json:"body"`
+}
+
+// contextBundle is the document `tl context` prints. Omitted and Truncated
+// name the sections cut to fit the byte budget.
+type contextBundle struct {
+	ID        string           `json:"id"`
+	Sections  []contextSection `json:"sections"`
+	Omitted   []string         `json:"omitted,omitempty"`
+	Truncated []string         `json:"truncated,omitempty"`
+}
+
+// buildContext assembles the bundle for id. root is the repository root the
+// task's spec path is resolved against.
+func buildContext(graph *Graph, events []Event, root, id string) (*contextBundle, error) {
+	issue, ok := graph.Tasks[id]
+	if !ok {
+		return nil, fmt.Errorf("issue %q: %w", id, ErrNotFound)
+	}
+	edges := contextEdges(events, id)
+
+	bundle := &contextBundle{ID: id}
+	add := func(name, heading, body string) {
+		if body = strings.TrimSpace(body); body != "" {
+			bundle.Sections = append(bundle.Sections, contextSection{Name: name, Heading: heading, Body: body})
+		}
+	}
+
+	add(contextTask, fmt.Sprintf("# %s: %s", issue.ID, issue.Title), contextTaskBody(issue))
+	var blockers, related []string
+	for _, edge := range edges {
+		switch {
+		case edge.From == id && edge.Type == DepParentChild:
+			if parent, ok := graph.Tasks[edge.To]; ok {
+				add(contextParent, fmt.Sprintf("## Parent: %s %s", parent.ID, parent.Title), contextParentBody(graph, parent))
+			}
+		case edge.From == id && edge.Type.AffectsReadyWork():
+			blockers = append(blockers, contextLinkLine(graph, edge.To, string(edge.Type)))
+		case edge.From == id && edge.Type == DepRelated, edge.To == id && edge.Type == DepRelated:
+			related = append(related, contextLinkLine(graph, edge.other(id), "related"))
+		case edge.From == id && edge.Type == DepDiscoveredFrom:
+			related = append(related, contextLinkLine(graph, edge.To, "discovered from"))
+		case edge.To == id && edge.Type == DepDiscoveredFrom:
+			related = append(related, contextLinkLine(graph, edge.From, "discovered here"))
+		}
+	}
+	add(contextBlockers, "## Blockers", strings.Join(blockers, "\n"))
+	if issue.SpecID != "" {
+		add(contextSpec, "## Spec: "+issue.SpecID, readSpec(root, issue.SpecID))
+	}
+	add(contextNotes, "## Notes", issue.Notes)
+	add(contextRelated, "## Related", strings.Join(related, "\n"))
+	if len(issue.Comments) > 0 {
+		var buf bytes.Buffer
+		writeCommentThread(&buf, issue, "")
+		add(contextComments, "## Comments", buf.String())
+	}
+	sort.SliceStable(bundle.Sections, func(i, j int) bool {
+		return contextRank(bundle.Sections[i].Name) < contextRank(bundle.Sections[j].Name)
+	})
+	return bundle, nil
+}
+
+// contextEdge is a dependency edge as recorded in the log. Closing a task
+// drops the edges pointing at it from the graph, so the bundle reads edges
+// from the events to still show finished blockers and their close reasons.
+type contextEdge struct {
+	From, To string
+	Type     DependencyType
+}
+
+func (e contextEdge) other(id string) string {
+	if e.From == id {
+		return e.To
+	}
+	return e.From
+}
+
+// contextEdges returns the edges touching id that were added and not later
+// removed, in the order they were first added.
+func contextEdges(events []Event, id string) []contextEdge {
+	var edges []contextEdge
+	for _, edge := range loggedEdges(events) {
+		if edge.From == id || edge.To == id {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// loggedEdges returns every edge that was added and not later removed, in
+// the order they were first added, including edges to closed tasks.
+func loggedEdges(events []Event) []contextEdge {
+	var order [][2]string
+	live := make(map[[2]string]DependencyType)
+	for _, evt := range events {
+		switch evt.Type {
+		case EventDepAdd:
+			var data DepAddEventData
+			if json.Unmarshal(evt.Data, &data) != nil {
+				continue
+			}
+			key := [2]string{evt.ID, data.DependsOnID}
+			if _, seen := live[key]; !seen {
+				order = append(order, key)
+			}
+			live[key] = DependencyType(data.DepType)
+		case EventDepRemove:
+			var data DepRemoveEventData
+			if json.Unmarshal(evt.Data, &data) == nil {
+				delete(live, [2]string{evt.ID, data.DependsOnID})
+			}
+		}
+	}
+
+	var edges []contextEdge
+	for _, key := range order {
+		if depType, ok := live[key]; ok {
+			edges = append(edges, contextEdge{From: key[0], To: key[1], Type: depType})
+			delete(live, key)
+		}
+	}
+	return edges
+}
+
+func contextTaskBody(issue *Issue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "- status: %s\n- priority: P%d\n", issue.Status, issue.Priority)
+	if issue.IssueType != "" {
+		fmt.Fprintf(&b, "- type: %s\n", issue.IssueType)
+	}
+	if issue.Assignee != "" {
+		fmt.Fprintf(&b, "- assignee: %s\n", issue.Assignee)
+	}
+	if len(issue.Labels) > 0 {
+		fmt.Fprintf(&b, "- labels: %s\n", strings.Join(issue.Labels, ", "))
+	}
+	for _, field := range []struct{ name, text string }{
+		{"Description", issue.Description},
+		{"Design", issue.Design},
+		{"Acceptance criteria", issue.AcceptanceCriteria},
+	} {
+		if text := strings.TrimSpace(field.text); text != "" {
+			fmt.Fprintf(&b, "\n### %s\n\n%s\n", field.name, text)
+		}
+	}
+	return b.String()
+}
+
+func contextParentBody(graph *Graph, parent *Issue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "- status: %s\n", parent.Status)
+	if parent.IssueType == TypeEpic {
+		progress := computeEpicProgress(graph, parent)
+		fmt.Fprintf(&b, "- progress: %d/%d closed\n", progress.Closed, progress.Total)
+	}
+	if text := strings.TrimSpace(parent.Description); text != "" {
+		fmt.Fprintf(&b, "\n%s\n", text)
+	}
+	return b.String()
+}
+
+func contextLinkLine(graph *Graph, id, relation string) string {
+	target, ok := graph.Tasks[id]
+	if !ok {
+		if ref, remote := graph.remote[id]; remote && ref.Issue != nil {
+			return fmt.Sprintf("- %s [%s] %s (%s, remote)", id, ref.Issue.Status, ref.Issue.Title, relation)
+		}
+		return fmt.Sprintf("- %s (%s, unknown)", id, relation)
+	}
+	line := fmt.Sprintf("- %s [%s] P%d %s (%s)", target.ID, target.Status, target.Priority, target.Title, relation)
+	if target.Status == StatusClosed && target.CloseReason != "" {
+		line += ": closed: " + target.CloseReason
+	}
+	return line
+}
+
+// readSpec returns the linked spec's text. specID may be a path inside the
+// repository or the name of an openspec spec or change; anything that
+// resolves outside root is refused rather than read.
+func readSpec(root, specID string) string {
+	candidates := []string{
+		specID,
+		filepath.Join("openspec", "specs", specID, "spec.md"),
+		filepath.Join("openspec", "changes", specID, "proposal.md"),
+	}
+	for _, candidate := range candidates {
+		path := filepath.Join(root, candidate)
+		if rel, err := filepath.Rel(root, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Sprintf("(could not read %s: %v)", candidate, err)
+		}
+		return string(data)
+	}
+	return "(spec not found in repository)"
+}
+
+// renderContextMarkdown prints the bundle as one Markdown document, ending
+// with a note of anything cut to fit the budget.
+func renderContextMarkdown(bundle *contextBundle) ([]byte, error) {
+	var b bytes.Buffer
+	for i, section := range bundle.Sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if section.Body == "" {
+			fmt.Fprintf(&b, "%s\n", section.Heading)
+			continue
+		}
+		fmt.Fprintf(&b, "%s\n\n%s\n", section.Heading, section.Body)
+	}
+	if len(bundle.Omitted) > 0 || len(bundle.Truncated) > 0 {
+		var parts []string
+		if len(bundle.Omitted) > 0 {
+			parts = append(parts, "omitted "+strings.Join(bundle.Omitted, ", "))
+		}
+		if len(bundle.Truncated) > 0 {
+			parts = append(parts, "truncated "+strings.Join(bundle.Truncated, ", "))
+		}
+		fmt.Fprintf(&b, "\n_Context trimmed to fit: %s._\n", strings.Join(parts, "; "))
+	}
+	return b.Bytes(), nil
+}
+
+func renderContextJSON(bundle *contextBundle) ([]byte, error) {
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// fitContext trims the bundle until render fits maxBytes, taking from the
+// least important section first: a section is shortened when cutting its
+// tail is enough, otherwise dropped. The task section is shortened but kept,
+// so a tiny budget can still come out over. maxBytes <= 0 means no limit.
+func fitContext(bundle *contextBundle, maxBytes int, render func(*contextBundle) ([]byte, error)) ([]byte, error) {
+	out, err := render(bundle)
+	if err != nil || maxBytes <= 0 {
+		return out, err
+	}
+	for len(out) > maxBytes {
+		index := lowestPrioritySection(bundle)
+		if index < 0 {
+			break
+		}
+		section := &bundle.Sections[index]
+		body := strings.TrimSuffix(section.Body, contextTruncatedMarker)
+		keep := len(section.Body) - (len(out) - maxBytes) - len(contextTruncatedMarker)
+		switch {
+		case keep >= contextMinBody || (keep > 0 && section.Name == contextTask):
+			section.Body = truncateUTF8(body, min(keep, len(body)-1)) + contextTruncatedMarker
+			bundle.Truncated = appendUnique(bundle.Truncated, section.Name)
+		case section.Name == contextTask:
+			section.Body = ""
+			bundle.Truncated = appendUnique(bundle.Truncated, section.Name)
+		default:
+			bundle.Omitted = append(bundle.Omitted, section.Name)
+			bundle.Truncated = removeString(bundle.Truncated, section.Name)
+			bundle.Sections = append(bundle.Sections[:index], bundle.Sections[index+1:]...)
+		}
+		if out, err = render(bundle); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// lowestPrioritySection returns the index of the least important section that
+// can still shrink, or -1.
+func lowestPrioritySection(bundle *contextBundle) int {
+	best, bestRank := -1, -1
+	for i, section := range bundle.Sections {
+		rank := contextRank(section.Name)
+		if section.Name == contextTask && section.Body == "" {
+			continue
+		}
+		if rank >= bestRank {
+			best, bestRank = i, rank
+		}
+	}
+	return best
+}
+
+// truncateUTF8 cuts s to at most n bytes without splitting a rune.
+func truncateUTF8(s string, n int) string {
+	if n >= len(s) {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+func contextRank(name string) int {
+	for i, v := range contextPriority {
+		if v == name {
+			return i
+		}
+	}
+	return len(contextPriority)
+}
+
+func appendUnique(values []string, value string) []string {
+	if containsString(values, value) {
+		return values
+	}
+	return append(values, value)
+}
@@ -0,0 +1,128 @@
+// ABOUTME: Tests `tl context`: section assembly from the graph, spec lookup and byte-budget trimming.
+// ABOUTME: Trimming must shorten or drop the least important sections first and keep the task.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seedContextRepo(t *testing.T) string {
+	t.Helper()
+	ts := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	epic, err := json.Marshal(CreateEventData{Title: "Checkout", Description: "Rebuild checkout.", Status: string(StatusOpen), Priority: 1, IssueType: string(TypeEpic)})
+	require.NoError(t, err)
+	task, err := json.Marshal(CreateEventData{Title: "Pay button", Description: "Add the pay button.", Status: string(StatusOpen), Priority: 1, Labels: []string{"ui"}, SpecID: "pay"})
+	require.NoError(t, err)
+	note, err := json.Marshal(NoteEventData{Text: "tried Stripe elements, too heavy"})
+	require.NoError(t, err)
+	dir := seedCommandRepoWithEvents(t,
+		Event{Type: EventCreate, ID: "tl-epic", Timestamp: ts, Actor: "test", Data: epic},
+		Event{Type: EventCreate, ID: "tl-task", Timestamp: ts, Actor: "test", Data: task},
+		createIssueEvent(t, "tl-api", "Payments API", StatusOpen, 0, ts),
+		createIssueEvent(t, "tl-bug", "Rounding bug", StatusOpen, 2, ts),
+		createIssueEvent(t, "tl-old", "Old button", StatusOpen, 3, ts),
+		depAddEvent(t, "tl-task", "tl-epic", DepParentChild, ts),
+		depAddEvent(t, "tl-task", "tl-api", DepBlocks, ts),
+		closeIssueEvent(t, "tl-api", "shipped v2", ts.Add(time.Minute)),
+		depAddEvent(t, "tl-bug", "tl-task", DepDiscoveredFrom, ts),
+		depAddEvent(t, "tl-task", "tl-old", DepRelated, ts),
+		Event{Type: EventNote, ID: "tl-task", Timestamp: ts.Add(2 * time.Minute), Actor: "agent-1", Data: note},
+	)
+	specDir := filepath.Join(filepath.Dir(dir), "openspec", "specs", "pay")
+	require.NoError(t, os.MkdirAll(specDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "spec.md"), []byte("The pay button submits the order.\n"), 0644))
+	return dir
+}
+
+func runContextOutput(t *testing.T, dir string, json bool, maxBytes int) string {
+	t.Helper()
+	setCommandGlobals(t, dir, json)
+	prev := contextMaxBytes
+	t.Cleanup(func() { contextMaxBytes = prev })
+	contextMaxBytes = maxBytes
+	cmd := newTestCommand()
+	require.NoError(t, runContext(cmd, []string{"tl-task"}))
+	return cmd.OutOrStdout().(*bytes.Buffer).String()
+}
+
+func TestContextAssemblesSections(t *testing.T) {
+	dir := seedContextRepo(t)
+	out := runContextOutput(t, dir, false, 0)
+
+	assert.True(t, strings.HasPrefix(out, "# tl-task: Pay button\n\n- status: open\n- priority: P1\n- labels: ui\n\n### Description\n\nAdd the pay button.\n"), out)
+	assert.Contains(t, out, "## Blockers\n\n- tl-api [closed] P0 Payments API (blocks): closed: shipped v2\n")
+	assert.Contains(t, out, "## Parent: tl-epic Checkout\n\n- status: open\n- progress: 0/1 closed\n\nRebuild checkout.\n")
+	assert.Contains(t, out, "## Spec: pay\n\nThe pay button submits the order.\n")
+	assert.Contains(t, out, "## Notes\n\n[2026-03-04T09:02:00Z agent-1] tried Stripe elements, too heavy\n")
+	assert.Contains(t, out, "## Related\n\n- tl-bug [open] P2 Rounding bug (discovered here)\n- tl-old [open] P3 Old button (related)\n")
+	assert.NotContains(t, out, "trimmed")
+
+	var bundle contextBundle
+	require.NoError(t, json.Unmarshal([]byte(runContextOutput(t, dir, true, 0)), &bundle))
+	names := make([]string, 0, len(bundle.Sections))
+	for _, section := range bundle.Sections {
+		names = append(names, section.Name)
+	}
+	assert.Equal(t, []string{contextTask, contextBlockers, contextParent, contextSpec, contextNotes, contextRelated}, names)
+
+	setCommandGlobals(t, dir, false)
+	assert.ErrorIs(t, runContext(newTestCommand(), []string{"tl-nope"}), ErrNotFound)
+}
+
+func TestContextTrimsToBudget(t *testing.T) {
+	dir := seedContextRepo(t)
+	full := runContextOutput(t, dir, false, 0)
+
+	budget := len(full) - 40
+	out := runContextOutput(t, dir, false, budget)
+	assert.LessOrEqual(t, len(out), budget)
+	assert.NotContains(t, out, "## Related")
+	assert.Contains(t, out, "## Notes")
+	assert.Contains(t, out, "_Context trimmed to fit: omitted related._\n")
+
+	out = runContextOutput(t, dir, false, 150)
+	assert.LessOrEqual(t, len(out), 150)
+	assert.True(t, strings.HasPrefix(out, "# tl-task: Pay button\n"))
+	assert.Contains(t, out, "[truncated]")
+
+	var bundle contextBundle
+	raw := runContextOutput(t, dir, true, 400)
+	assert.LessOrEqual(t, len(raw), 400)
+	require.NoError(t, json.Unmarshal([]byte(raw), &bundle))
+	assert.Equal(t, contextTask, bundle.Sections[0].Name)
+	assert.Contains(t, bundle.Omitted, contextRelated)
+}
+
+func TestFitContextReturnsRenderErrors(t *testing.T) {
+	failed := errors.New("render failed")
+	bundle := &contextBundle{ID: "tl-a", Sections: []contextSection{{Name: contextTask, Heading: "# tl-a", Body: "body"}}}
+	for _, maxBytes := range []int{0, 4} {
+		out, err := fitContext(bundle, maxBytes, func(*contextBundle) ([]byte, error) { return nil, failed })
+		assert.ErrorIs(t, err, failed)
+		assert.Nil(t, out)
+	}
+}
+
+func TestReadSpecStaysInsideRepo(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "SPEC.md"), []byte("local spec"), 0644))
+	assert.Equal(t, "local spec", readSpec(root, "SPEC.md"))
+	assert.Equal(t, "(spec not found in repository)", readSpec(root, "../../etc/passwd"))
+	assert.Equal(t, "(spec not found in repository)", readSpec(root, "missing"))
+}
+
+func TestTruncateUTF8(t *testing.T) {
+	assert.Equal(t, "h", truncateUTF8("hé", 2))
+	assert.Equal(t, "hé", truncateUTF8("hé", 3))
+}
@@ -0,0 +1,332 @@
+// ABOUTME: Critical path and slack analysis over the blocking dependency graph of open tasks.
+// ABOUTME: Weights each task by its estimate (default 1) and computes earliest/latest schedule bounds.
+
+package tl
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// estimateMetadataKey is the beads field carrying a task's estimate; tl keeps it in Metadata.
+const estimateMetadataKey = "estimated_minutes"
+
+type criticalPathTask struct {
+	ID             string `json:"id"`
+	Title          string `json:"title"`
+	Status         Status `json:"status"`
+	Estimate       int    `json:"estimate"`
+	EarliestStart  int    `json:"earliest_start"`
+	EarliestFinish int    `json:"earliest_finish"`
+	LatestStart    int    `json:"latest_start"`
+	LatestFinish   int    `json:"latest_finish"`
+	Slack          int    `json:"slack"`
+	Critical       bool   `json:"critical"`
+	Ready          bool   `json:"ready"`
+}
+
+type criticalPathResult struct {
+	Scope         string             `json:"scope,omitempty"`
+	Length        int                `json:"length"`
+	Path          []string           `json:"path"`
+	ReadyCritical []string           `json:"ready_critical"`
+	Tasks         []criticalPathTask `json:"tasks"`
+}
+
+// affectsSchedule reports whether an edge type orders work for critical path
+// purposes. A parent-child edge orders a child after its open task parent,
+// matching the ready queue; epics are not scheduled at all (see criticalPath),
+// so their parent-child edges only define scope.
+func affectsSchedule(t DependencyType) bool {
+	return t == DepBlocks || t == DepParentChild || t == DepWaitsFor
+}
+
+// issueEstimate returns the task's estimate, falling back to 1 when unset or invalid.
+func issueEstimate(issue *Issue) int {
+	raw, ok := issue.Metadata[estimateMetadataKey]
+	if !ok {
+		return 1
+	}
+	var estimate int
+	if err := json.Unmarshal(raw, &estimate); err != nil || estimate <= 0 {
+		return 1
+	}
+	return estimate
+}
+
+// childIDs returns the IDs of issues that declare a parent-child dependency on parentID.
+func childIDs(graph *Graph, parentID string) []string {
+	var children []string
+	seen := make(map[string]bool)
+	for _, id := range graph.RDeps[parentID] {
+		if seen[id] {
+			continue
+		}
+		issue, ok := graph.Tasks[id]
+		if !ok {
+			continue
+		}
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.DependsOnID == parentID && dep.Type == DepParentChild {
+				children = append(children, id)
+				seen[id] = true
+				break
+			}
+		}
+	}
+	sort.Strings(children)
+	return children
+}
+
+// descendantIDs returns every transitive parent-child descendant of rootID.
+func descendantIDs(graph *Graph, rootID string) map[string]bool {
+	out := make(map[string]bool)
+	stack := childIDs(graph, rootID)
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if out[id] || id == rootID {
+			continue
+		}
+		out[id] = true
+		stack = append(stack, childIDs(graph, id)...)
+	}
+	return out
+}
+
+// computeCriticalPath schedules the open tasks (optionally limited to the
+// descendants of scopeID) and returns the longest weighted chain plus slack per task.
+// It fails with ErrCycle when the scheduling edges are not acyclic.
+func computeCriticalPath(graph *Graph, scopeID string, ready []*Issue) (criticalPathResult, error) {
+	return criticalPath(graph, scopeID, ready, false)
+}
+
+// computeCriticalPathSkippingCycles is computeCriticalPath for ranking: tasks
+// caught in a cycle, or waiting behind one, are left out of the schedule
+// rather than failing it, since import can let cycles into the log.
+func computeCriticalPathSkippingCycles(graph *Graph, ready []*Issue) criticalPathResult {
+	result, _ := criticalPath(graph, "", ready, true)
+	return result
+}
+
+func criticalPath(graph *Graph, scopeID string, ready []*Issue, skipCycles bool) (criticalPathResult, error) {
+	result := criticalPathResult{Scope: scopeID, Path: []string{}, ReadyCritical: []string{}, Tasks: []criticalPathTask{}}
+	if graph == nil {
+		return result, nil
+	}
+
+	var scope map[string]bool
+	if scopeID != "" {
+		if _, ok := graph.Tasks[scopeID]; !ok {
+			return result, fmt.Errorf("issue %q: %w", scopeID, ErrNotFound)
+		}
+		scope = descendantIDs(graph, scopeID)
+	}
+
+	nodes := make(map[string]*Issue)
+	for id, issue := range graph.Tasks {
+		if issue.Status == StatusClosed || issue.Status == StatusPinned || issue.Pinned {
+			continue
+		}
+		// An epic is a container: it is done when its children are, so it
+		// adds no work of its own and never sits on the path.
+		if issue.IssueType == TypeEpic {
+			continue
+		}
+		if scope != nil && !scope[id] {
+			continue
+		}
+		nodes[id] = issue
+	}
+
+	// preds[v] are the tasks that must finish before v; succs is the inverse.
+	preds := make(map[string][]string)
+	succs := make(map[string][]string)
+	for id, issue := range nodes {
+		seen := make(map[string]bool)
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !affectsSchedule(dep.Type) || seen[dep.DependsOnID] {
+				continue
+			}
+			if _, ok := nodes[dep.DependsOnID]; !ok {
+				continue
+			}
+			seen[dep.DependsOnID] = true
+			preds[id] = append(preds[id], dep.DependsOnID)
+			succs[dep.DependsOnID] = append(succs[dep.DependsOnID], id)
+		}
+	}
+
+	order, err := topoOrder(nodes, preds, succs)
+	if err != nil {
+		if !skipCycles {
+			return result, err
+		}
+		nodes, succs = scheduledOnly(order, nodes, succs)
+	}
+
+	earliestFinish := make(map[string]int, len(order))
+	for _, id := range order {
+		start := 0
+		for _, p := range preds[id] {
+			if earliestFinish[p] > start {
+				start = earliestFinish[p]
+			}
+		}
+		earliestFinish[id] = start + issueEstimate(nodes[id])
+		if earliestFinish[id] > result.Length {
+			result.Length = earliestFinish[id]
+		}
+	}
+
+	latestFinish := make(map[string]int, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		id := order[i]
+		finish := result.Length
+		for _, s := range succs[id] {
+			if ls := latestFinish[s] - issueEstimate(nodes[s]); ls < finish {
+				finish = ls
+			}
+		}
+		latestFinish[id] = finish
+	}
+
+	readySet := make(map[string]bool, len(ready))
+	for _, issue := range ready {
+		readySet[issue.ID] = true
+	}
+
+	for _, id := range order {
+		estimate := issueEstimate(nodes[id])
+		task := criticalPathTask{
+			ID:             id,
+			Title:          nodes[id].Title,
+			Status:         nodes[id].Status,
+			Estimate:       estimate,
+			EarliestStart:  earliestFinish[id] - estimate,
+			EarliestFinish: earliestFinish[id],
+			LatestStart:    latestFinish[id] - estimate,
+			LatestFinish:   latestFinish[id],
+			Slack:          latestFinish[id] - earliestFinish[id],
+			Ready:          readySet[id],
+		}
+		task.Critical = task.Slack == 0
+		result.Tasks = append(result.Tasks, task)
+	}
+	sort.SliceStable(result.Tasks, func(i, j int) bool {
+		if result.Tasks[i].Slack != result.Tasks[j].Slack {
+			return result.Tasks[i].Slack < result.Tasks[j].Slack
+		}
+		if result.Tasks[i].EarliestStart != result.Tasks[j].EarliestStart {
+			return result.Tasks[i].EarliestStart < result.Tasks[j].EarliestStart
+		}
+		return result.Tasks[i].ID < result.Tasks[j].ID
+	})
+
+	result.Path = longestChain(order, preds, earliestFinish, nodes, result.Length)
+
+	// ready is already in queue order, so the first entries are what agents should pick.
+	for _, issue := range ready {
+		if _, ok := nodes[issue.ID]; ok && latestFinish[issue.ID] == earliestFinish[issue.ID] {
+			result.ReadyCritical = append(result.ReadyCritical, issue.ID)
+		}
+	}
+
+	return result, nil
+}
+
+// scheduledOnly drops the nodes topoOrder could not place. Every predecessor
+// of a placed node is placed too, so only the successor lists need pruning.
+func scheduledOnly(order []string, nodes map[string]*Issue, succs map[string][]string) (map[string]*Issue, map[string][]string) {
+	kept := make(map[string]*Issue, len(order))
+	for _, id := range order {
+		kept[id] = nodes[id]
+	}
+	keptSuccs := make(map[string][]string, len(succs))
+	for _, id := range order {
+		for _, s := range succs[id] {
+			if _, ok := kept[s]; ok {
+				keptSuccs[id] = append(keptSuccs[id], s)
+			}
+		}
+	}
+	return kept, keptSuccs
+}
+
+// topoOrder returns nodes in dependency order (Kahn's algorithm, ties broken by ID).
+// It fails with ErrCycle when the blocking edges among nodes are not acyclic,
+// still returning the nodes it could order.
+func topoOrder(nodes map[string]*Issue, preds, succs map[string][]string) ([]string, error) {
+	indegree := make(map[string]int, len(nodes))
+	var queue []string
+	for id := range nodes {
+		indegree[id] = len(preds[id])
+		if indegree[id] == 0 {
+			queue = append(queue, id)
+		}
+	}
+	sort.Strings(queue)
+
+	order := make([]string, 0, len(nodes))
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		order = append(order, id)
+
+		next := append([]string(nil), succs[id]...)
+		sort.Strings(next)
+		for _, s := range next {
+			indegree[s]--
+			if indegree[s] == 0 {
+				queue = append(queue, s)
+			}
+		}
+	}
+
+	if len(order) != len(nodes) {
+		var stuck []string
+		for id, n := range indegree {
+			if n > 0 {
+				stuck = append(stuck, id)
+			}
+		}
+		sort.Strings(stuck)
+		return order, fmt.Errorf("%w among: %s", ErrCycle, strings.Join(stuck, " "))
+	}
+	return order, nil
+}
+
+// longestChain walks back from the task finishing last along predecessors whose
+// finish time exactly meets the next task's start, yielding the critical chain.
+func longestChain(order []string, preds map[string][]string, earliestFinish map[string]int, nodes map[string]*Issue, length int) []string {
+	chain := []string{}
+	if length == 0 {
+		return chain
+	}
+
+	current := ""
+	for _, id := range order {
+		if earliestFinish[id] == length && (current == "" || id < current) {
+			current = id
+		}
+	}
+
+	for current != "" {
+		chain = append(chain, current)
+		start := earliestFinish[current] - issueEstimate(nodes[current])
+		next := ""
+		for _, p := range preds[current] {
+			if earliestFinish[p] == start && (next == "" || p < next) {
+				next = p
+			}
+		}
+		current = next
+	}
+
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain
+}
@@ -0,0 +1,133 @@
+// ABOUTME: Tests critical path scheduling over weighted blocking edges.
+// ABOUTME: Verifies longest chain selection, slack, epic scoping and exclusion, estimates, and cycle errors.
+
+package tl
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func criticalPathGraph(t *testing.T) *Graph {
+	t.Helper()
+	now := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	return &Graph{
+		Tasks: map[string]*Issue{
+			"tl-a": {ID: "tl-a", Title: "A", Status: StatusOpen, CreatedAt: now},
+			"tl-b": {
+				ID: "tl-b", Title: "B", Status: StatusOpen, CreatedAt: now,
+				Metadata: map[string]json.RawMessage{estimateMetadataKey: json.RawMessage(`3`)},
+				Dependencies: []*Dependency{
+					{IssueID: "tl-b", DependsOnID: "tl-a", Type: DepBlocks},
+				},
+			},
+			"tl-c": {
+				ID: "tl-c", Title: "C", Status: StatusOpen, CreatedAt: now,
+				Dependencies: []*Dependency{
+					{IssueID: "tl-c", DependsOnID: "tl-a", Type: DepWaitsFor},
+				},
+			},
+			"tl-d": {
+				ID: "tl-d", Title: "D", Status: StatusOpen, CreatedAt: now,
+				Dependencies: []*Dependency{
+					{IssueID: "tl-d", DependsOnID: "tl-b", Type: DepBlocks},
+					{IssueID: "tl-d", DependsOnID: "tl-c", Type: DepBlocks},
+				},
+			},
+			"tl-e": {
+				ID: "tl-e", Title: "E", Status: StatusOpen, CreatedAt: now,
+				Dependencies: []*Dependency{
+					{IssueID: "tl-e", DependsOnID: "tl-a", Type: DepRelated},
+				},
+			},
+		},
+	}
+}
+
+func TestComputeCriticalPathLongestChainAndSlack(t *testing.T) {
+	graph := criticalPathGraph(t)
+	ready := []*Issue{graph.Tasks["tl-a"], graph.Tasks["tl-e"]}
+
+	result, err := computeCriticalPath(graph, "", ready)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, result.Length)
+	assert.Equal(t, []string{"tl-a", "tl-b", "tl-d"}, result.Path)
+	assert.Equal(t, []string{"tl-a"}, result.ReadyCritical)
+
+	byID := make(map[string]criticalPathTask)
+	for _, task := range result.Tasks {
+		byID[task.ID] = task
+	}
+	assert.Equal(t, 0, byID["tl-a"].Slack)
+	assert.Equal(t, 3, byID["tl-b"].Estimate)
+	assert.Equal(t, 2, byID["tl-c"].Slack)
+	assert.Equal(t, 4, byID["tl-e"].Slack)
+	assert.True(t, byID["tl-d"].Critical)
+	assert.False(t, byID["tl-c"].Critical)
+	assert.True(t, byID["tl-a"].Ready)
+}
+
+func TestComputeCriticalPathScopedToEpic(t *testing.T) {
+	graph := criticalPathGraph(t)
+	now := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	graph.Tasks["tl-epic"] = &Issue{ID: "tl-epic", Title: "Epic", Status: StatusOpen, IssueType: TypeEpic, CreatedAt: now}
+	for _, id := range []string{"tl-c", "tl-d"} {
+		graph.Tasks[id].Dependencies = append(graph.Tasks[id].Dependencies, &Dependency{IssueID: id, DependsOnID: "tl-epic", Type: DepParentChild})
+	}
+	graph.RDeps = map[string][]string{"tl-epic": {"tl-c", "tl-d"}}
+
+	result, err := computeCriticalPath(graph, "tl-epic", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "tl-epic", result.Scope)
+	assert.Equal(t, []string{"tl-c", "tl-d"}, result.Path)
+	assert.Len(t, result.Tasks, 2)
+}
+
+func TestComputeCriticalPathLeavesEpicsOutOfUnscopedSchedule(t *testing.T) {
+	graph := criticalPathGraph(t)
+	now := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	graph.Tasks["tl-epic"] = &Issue{ID: "tl-epic", Title: "Epic", Status: StatusOpen, IssueType: TypeEpic, CreatedAt: now,
+		Metadata: map[string]json.RawMessage{estimateMetadataKey: json.RawMessage(`10`)}}
+	for _, id := range []string{"tl-a", "tl-b"} {
+		graph.Tasks[id].Dependencies = append(graph.Tasks[id].Dependencies, &Dependency{IssueID: id, DependsOnID: "tl-epic", Type: DepParentChild})
+	}
+	graph.RDeps = map[string][]string{"tl-epic": {"tl-a", "tl-b"}}
+
+	result, err := computeCriticalPath(graph, "", []*Issue{graph.Tasks["tl-a"], graph.Tasks["tl-epic"]})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tl-a", "tl-b", "tl-d"}, result.Path, "the epic neither precedes its children nor adds to the path")
+	assert.Equal(t, 5, result.Length)
+	assert.Equal(t, []string{"tl-a"}, result.ReadyCritical)
+	for _, task := range result.Tasks {
+		assert.NotEqual(t, "tl-epic", task.ID)
+	}
+}
+
+func TestComputeCriticalPathSkipsClosedTasks(t *testing.T) {
+	graph := criticalPathGraph(t)
+	graph.Tasks["tl-a"].Status = StatusClosed
+
+	result, err := computeCriticalPath(graph, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.Length)
+	assert.Equal(t, []string{"tl-b", "tl-d"}, result.Path)
+}
+
+func TestComputeCriticalPathReportsCycle(t *testing.T) {
+	graph := criticalPathGraph(t)
+	graph.Tasks["tl-a"].Dependencies = []*Dependency{{IssueID: "tl-a", DependsOnID: "tl-d", Type: DepBlocks}}
+
+	_, err := computeCriticalPath(graph, "", nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrCycle)
+}
+
+func TestComputeCriticalPathUnknownScope(t *testing.T) {
+	_, err := computeCriticalPath(criticalPathGraph(t), "tl-missing", nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,151 @@
+// ABOUTME: Minimal five-field cron expressions (minute hour day-of-month month day-of-week) for recurring schedules.
+// ABOUTME: Supports *, lists, ranges, steps and the @hourly/@daily/@weekly/@monthly/@yearly shorthands.
+
+package tl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronExpr is a parsed cron expression; each field is the set of matching values.
+type cronExpr struct {
+	minute, hour, dom, month, dow [64]bool
+	// domAny/dowAny record a "*" field; when both day fields are restricted
+	// a day matches if either does, as in classic cron.
+	domAny, dowAny bool
+}
+
+var cronShorthands = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+	"@yearly":  "0 0 1 1 *",
+}
+
+// cronLookback bounds the backwards search for an occurrence; a leap-day
+// rule recurs at least once in this window.
+const cronLookback = 8 * 366
+
+func parseCron(raw string) (*cronExpr, error) {
+	spec := strings.TrimSpace(raw)
+	if expanded, ok := cronShorthands[strings.ToLower(spec)]; ok {
+		spec = expanded
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron %q: want 5 fields (minute hour day month weekday)", raw)
+	}
+
+	expr := &cronExpr{domAny: fields[2] == "*", dowAny: fields[4] == "*"}
+	specs := []struct {
+		set      *[64]bool
+		min, max int
+		name     string
+	}{
+		{&expr.minute, 0, 59, "minute"},
+		{&expr.hour, 0, 23, "hour"},
+		{&expr.dom, 1, 31, "day of month"},
+		{&expr.month, 1, 12, "month"},
+		{&expr.dow, 0, 7, "day of week"},
+	}
+	for i, s := range specs {
+		if err := parseCronField(fields[i], s.min, s.max, s.set); err != nil {
+			return nil, fmt.Errorf("invalid cron %q: %s: %w", raw, s.name, err)
+		}
+	}
+	// 7 is an alias for Sunday.
+	if expr.dow[7] {
+		expr.dow[0] = true
+	}
+	return expr, nil
+}
+
+func parseCronField(field string, min, max int, set *[64]bool) error {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if base, stepText, ok := strings.Cut(part, "/"); ok {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("bad step %q", stepText)
+			}
+			rangePart, step = base, n
+		}
+
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			a, b, _ := strings.Cut(rangePart, "-")
+			var errA, errB error
+			lo, errA = strconv.Atoi(a)
+			hi, errB = strconv.Atoi(b)
+			if errA != nil || errB != nil || lo > hi {
+				return fmt.Errorf("bad range %q", rangePart)
+			}
+		default:
+			n, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return fmt.Errorf("bad value %q", rangePart)
+			}
+			lo, hi = n, n
+			if strings.Contains(part, "/") {
+				hi = max
+			}
+		}
+		if lo < min || hi > max {
+			return fmt.Errorf("%q out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return nil
+}
+
+func (c *cronExpr) matchesDay(t time.Time) bool {
+	if !c.month[int(t.Month())] {
+		return false
+	}
+	dom, dow := c.dom[t.Day()], c.dow[int(t.Weekday())]
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// prev returns the latest occurrence at or before t, in t's location, and
+// false if none falls within the lookback window.
+func (c *cronExpr) prev(t time.Time) (time.Time, bool) {
+	t = t.Truncate(time.Minute)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for i := 0; i < cronLookback; i++ {
+		if c.matchesDay(day) {
+			for h := 23; h >= 0; h-- {
+				if !c.hour[h] {
+					continue
+				}
+				for m := 59; m >= 0; m-- {
+					if !c.minute[m] {
+						continue
+					}
+					candidate := time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, day.Location())
+					if !candidate.After(t) {
+						return candidate, true
+					}
+				}
+			}
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,56 @@
+// ABOUTME: Tests the five-field cron parser and previous-occurrence search used by schedules.
+// ABOUTME: Covers shorthands, ranges, steps, lists, day-of-month/day-of-week semantics and invalid input.
+
+package tl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronPrev(t *testing.T) {
+	// Wednesday 2026-03-04 15:37.
+	now := time.Date(2026, 3, 4, 15, 37, 42, 0, time.UTC)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	cases := map[string]time.Time{
+		"* * * * *":       at(3, 4, 15, 37),
+		"@hourly":         at(3, 4, 15, 0),
+		"@daily":          at(3, 4, 0, 0),
+		"@weekly":         at(3, 1, 0, 0),
+		"@monthly":        at(3, 1, 0, 0),
+		"@yearly":         at(1, 1, 0, 0),
+		"0 9 * * 1":       at(3, 2, 9, 0),
+		"0 9 * * 1-5":     at(3, 4, 9, 0),
+		"30 16 * * *":     at(3, 3, 16, 30),
+		"*/15 * * * *":    at(3, 4, 15, 30),
+		"0 8,20 * * *":    at(3, 4, 8, 0),
+		"0 0 15 * *":      at(2, 15, 0, 0),
+		"0 0 15 * 3":      at(3, 4, 0, 0),
+		"0 0 * * 7":       at(3, 1, 0, 0),
+		"0 6 1 1-12/3 *":  at(1, 1, 6, 0),
+		"5/20 10 * * *":   at(3, 4, 10, 45),
+		"0 0 29 2 *":      time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		"37 15 4 3 *":     at(3, 4, 15, 37),
+		"0 12 * 2,4 1,3 ": at(2, 25, 12, 0),
+	}
+	for spec, want := range cases {
+		expr, err := parseCron(spec)
+		require.NoError(t, err, spec)
+		got, ok := expr.prev(now)
+		require.True(t, ok, spec)
+		assert.Equal(t, want, got, spec)
+	}
+}
+
+func TestParseCronRejectsInvalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@fortnightly"} {
+		_, err := parseCron(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -1,34 +1,276 @@
 // ABOUTME: Cycle detection for dependency graph using depth-first search.
-// ABOUTME: Used by dep add to reject cycles before appending dep_add events.
+// ABOUTME: Used by dep add to reject new cycles and by dep cycles/import to find existing ones (Tarjan SCC).
 
 package tl
 
+import (
+	"sort"
+	"strings"
+)
+
+// CycleError reports the dependency path that a rejected edge would close.
+// Path starts and ends with the same issue ID.
+type CycleError struct {
+	Path []string `json:"path"`
+}
+
+func (e *CycleError) Error() string {
+	return ErrCycle.Error() + ": " + strings.Join(e.Path, " -> ")
+}
+
+func (e *CycleError) Unwrap() error {
+	return ErrCycle
+}
+
 func hasCycle(graph *Graph, issueID, dependsOnID string) bool {
+	return cyclePath(graph, issueID, dependsOnID) != nil
+}
+
+// cyclePath returns the cycle issueID -> dependsOnID -> ... -> issueID that
+// adding a ready-affecting edge would create, or nil if the edge keeps the
+// graph acyclic. Like findDependencyCycles it follows only edges that affect
+// ready work, so related and discovered-from links never close a cycle.
+func cyclePath(graph *Graph, issueID, dependsOnID string) []string {
 	if graph == nil {
-		return false
+		return nil
 	}
 
+	parent := map[string]string{}
 	visited := map[string]bool{}
 	stack := []string{dependsOnID}
+	visited[dependsOnID] = true
 
 	for len(stack) > 0 {
 		current := stack[len(stack)-1]
 		stack = stack[:len(stack)-1]
 
 		if current == issueID {
-			return true
-		}
-		if visited[current] {
-			continue
+			path := []string{issueID}
+			for node := issueID; node != dependsOnID; node = parent[node] {
+				path = append(path, parent[node])
+			}
+			// path is issueID <- ... <- dependsOnID; reverse to follow the edges.
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return append([]string{issueID}, path...)
 		}
-		visited[current] = true
 
-		for _, next := range graph.Deps[current] {
+		for _, next := range readyEdgeTargets(graph, current) {
 			if !visited[next] {
+				visited[next] = true
+				parent[next] = current
 				stack = append(stack, next)
 			}
 		}
 	}
 
-	return false
+	return nil
+}
+
+// needsCycleCheck reports whether setting an edge of depType, replacing existing
+// (nil for a new edge), needs a cycle check: only an edge that starts to
+// affect ready work can close a ready-affecting cycle.
+func needsCycleCheck(existing *Dependency, depType DependencyType) bool {
+	return depType.AffectsReadyWork() && (existing == nil || !existing.Type.AffectsReadyWork())
+}
+
+// readyEdgeTargets lists what id depends on through ready-affecting edges.
+// An issue the graph has no record of falls back to its Deps adjacency.
+func readyEdgeTargets(graph *Graph, id string) []string {
+	issue, ok := graph.Tasks[id]
+	if !ok {
+		return graph.Deps[id]
+	}
+	var targets []string
+	for _, dep := range issue.Dependencies {
+		if dep != nil && dep.Type.AffectsReadyWork() {
+			targets = append(targets, dep.DependsOnID)
+		}
+	}
+	return targets
+}
+
+// cycleEdge is one ready-affecting edge inside a dependency cycle.
+type cycleEdge struct {
+	From string         `json:"from"`
+	To   string         `json:"to"`
+	Type DependencyType `json:"type"`
+}
+
+// dependencyCycle is a strongly connected component of ready-affecting edges.
+// Cycle is one representative loop through the component's smallest member.
+type dependencyCycle struct {
+	Members []string    `json:"members"`
+	Cycle   []cycleEdge `json:"cycle"`
+	Edges   []cycleEdge `json:"edges"`
+}
+
+// key identifies the component by its members, for comparing scans.
+func (c dependencyCycle) key() string {
+	return strings.Join(c.Members, " ")
+}
+
+// String renders the representative loop, e.g. "a -[blocks]-> b -[waits-for]-> a".
+func (c dependencyCycle) String() string {
+	if len(c.Cycle) == 0 {
+		return strings.Join(c.Members, " ")
+	}
+	var b strings.Builder
+	b.WriteString(c.Cycle[0].From)
+	for _, edge := range c.Cycle {
+		b.WriteString(" -[" + string(edge.Type) + "]-> " + edge.To)
+	}
+	return b.String()
+}
+
+// findDependencyCycles returns every cycle among edges that affect ready work,
+// sorted by smallest member. Edges to unknown issues are ignored.
+func findDependencyCycles(graph *Graph) []dependencyCycle {
+	if graph == nil {
+		return nil
+	}
+
+	adjacency := make(map[string][]cycleEdge)
+	ids := make([]string, 0, len(graph.Tasks))
+	for id, issue := range graph.Tasks {
+		ids = append(ids, id)
+		seen := make(map[string]bool)
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.AffectsReadyWork() || seen[dep.DependsOnID] {
+				continue
+			}
+			if _, ok := graph.Tasks[dep.DependsOnID]; !ok {
+				continue
+			}
+			seen[dep.DependsOnID] = true
+			adjacency[id] = append(adjacency[id], cycleEdge{From: id, To: dep.DependsOnID, Type: dep.Type})
+		}
+		sort.Slice(adjacency[id], func(i, j int) bool { return adjacency[id][i].To < adjacency[id][j].To })
+	}
+	sort.Strings(ids)
+
+	// Tarjan's strongly connected components.
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+	next := 0
+
+	var strongConnect func(id string)
+	strongConnect = func(id string) {
+		index[id] = next
+		lowlink[id] = next
+		next++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, edge := range adjacency[id] {
+			if _, visited := index[edge.To]; !visited {
+				strongConnect(edge.To)
+				lowlink[id] = min(lowlink[id], lowlink[edge.To])
+			} else if onStack[edge.To] {
+				lowlink[id] = min(lowlink[id], index[edge.To])
+			}
+		}
+
+		if lowlink[id] == index[id] {
+			var component []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == id {
+					break
+				}
+			}
+			components = append(components, component)
+		}
+	}
+
+	for _, id := range ids {
+		if _, visited := index[id]; !visited {
+			strongConnect(id)
+		}
+	}
+
+	var cycles []dependencyCycle
+	for _, component := range components {
+		members := make(map[string]bool, len(component))
+		for _, id := range component {
+			members[id] = true
+		}
+		sort.Strings(component)
+
+		var edges []cycleEdge
+		for _, id := range component {
+			for _, edge := range adjacency[id] {
+				if members[edge.To] {
+					edges = append(edges, edge)
+				}
+			}
+		}
+		if len(edges) == 0 {
+			continue
+		}
+
+		cycles = append(cycles, dependencyCycle{
+			Members: component,
+			Cycle:   representativeCycle(component[0], adjacency, members),
+			Edges:   edges,
+		})
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i].Members[0] < cycles[j].Members[0] })
+	return cycles
+}
+
+// representativeCycle finds the shortest loop from start back to itself
+// using only edges inside the component.
+func representativeCycle(start string, adjacency map[string][]cycleEdge, members map[string]bool) []cycleEdge {
+	via := make(map[string]cycleEdge)
+	queue := []string{start}
+	visited := map[string]bool{}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, edge := range adjacency[current] {
+			if !members[edge.To] {
+				continue
+			}
+			if edge.To == start {
+				path := []cycleEdge{edge}
+				for node := current; node != start; node = via[node].From {
+					path = append(path, via[node])
+				}
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			if !visited[edge.To] {
+				visited[edge.To] = true
+				via[edge.To] = edge
+				queue = append(queue, edge.To)
+			}
+		}
+	}
+	return nil
+}
+
+// introducedCycles returns the cycles in after whose member sets were not cyclic in before.
+func introducedCycles(before, after []dependencyCycle) []dependencyCycle {
+	known := make(map[string]bool, len(before))
+	for _, c := range before {
+		known[c.key()] = true
+	}
+	var out []dependencyCycle
+	for _, c := range after {
+		if !known[c.key()] {
+			out = append(out, c)
+		}
+	}
+	return out
 }
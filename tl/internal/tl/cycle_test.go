@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHasCycle(t *testing.T) {
@@ -30,3 +31,46 @@ func TestHasCycleNoCycle(t *testing.T) {
 
 	assert.False(t, hasCycle(graph, "tl-a", "tl-c"))
 }
+
+func TestCyclePathReturnsOffendingPath(t *testing.T) {
+	graph := &Graph{
+		Deps: map[string][]string{
+			"tl-a": {"tl-b"},
+			"tl-b": {"tl-c", "tl-x"},
+		},
+	}
+
+	assert.Equal(t, []string{"tl-c", "tl-a", "tl-b", "tl-c"}, cyclePath(graph, "tl-c", "tl-a"))
+	assert.Nil(t, cyclePath(graph, "tl-a", "tl-c"))
+}
+
+func TestCycleErrorWrapsErrCycle(t *testing.T) {
+	err := &CycleError{Path: []string{"tl-a", "tl-b", "tl-a"}}
+	assert.ErrorIs(t, err, ErrCycle)
+	assert.EqualError(t, err, "dependency would create a cycle: tl-a -> tl-b -> tl-a")
+}
+
+func TestFindDependencyCyclesReportsComponentsWithEdgeTypes(t *testing.T) {
+	graph := &Graph{
+		Tasks: map[string]*Issue{
+			"tl-a": {ID: "tl-a", Dependencies: []*Dependency{{DependsOnID: "tl-b", Type: DepBlocks}}},
+			"tl-b": {ID: "tl-b", Dependencies: []*Dependency{{DependsOnID: "tl-c", Type: DepWaitsFor}}},
+			"tl-c": {ID: "tl-c", Dependencies: []*Dependency{{DependsOnID: "tl-a", Type: DepParentChild}}},
+			"tl-d": {ID: "tl-d", Dependencies: []*Dependency{{DependsOnID: "tl-e", Type: DepBlocks}}},
+			"tl-e": {ID: "tl-e", Dependencies: []*Dependency{{DependsOnID: "tl-d", Type: DepRelated}}},
+			"tl-f": {ID: "tl-f", Dependencies: []*Dependency{{DependsOnID: "tl-a", Type: DepBlocks}}},
+		},
+	}
+
+	cycles := findDependencyCycles(graph)
+	require.Len(t, cycles, 1, "related edges do not form ready-affecting cycles")
+	assert.Equal(t, []string{"tl-a", "tl-b", "tl-c"}, cycles[0].Members)
+	assert.Len(t, cycles[0].Edges, 3)
+	assert.Equal(t, "tl-a -[blocks]-> tl-b -[waits-for]-> tl-c -[parent-child]-> tl-a", cycles[0].String())
+}
+
+func TestIntroducedCyclesIgnoresPreexisting(t *testing.T) {
+	old := dependencyCycle{Members: []string{"tl-a", "tl-b"}}
+	fresh := dependencyCycle{Members: []string{"tl-c", "tl-d"}}
+	assert.Equal(t, []dependencyCycle{fresh}, introducedCycles([]dependencyCycle{old}, []dependencyCycle{old, fresh}))
+}
@@ -0,0 +1,101 @@
+// ABOUTME: Deferral — parking a task until a wake-up time, after which it is ready again without any command.
+// ABOUTME: Parses --until values: timestamps, dates, durations like 3d or 2w, and names like tomorrow or next-monday.
+
+package tl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// deferLapsed reports whether issue is deferred until a time that has
+// passed. Such a task is treated as open: it shows up as ready and can be
+// claimed, just like a task whose lease expired.
+func deferLapsed(issue *Issue, now time.Time) bool {
+	return issue.Status == StatusDeferred && issue.DeferUntil != nil && !issue.DeferUntil.After(now)
+}
+
+// parseDeferUntil turns a --until value into an absolute time. Accepted:
+// RFC3339 timestamps, dates (2026-03-09, local midnight), Go durations (90m,
+// 4h), day and week counts (3d, 2w), "tomorrow" and "next-<weekday>".
+// Named days resolve to local midnight.
+func parseDeferUntil(raw string, now time.Time) (time.Time, error) {
+	value := strings.ToLower(strings.TrimSpace(raw))
+	if value == "" {
+		return time.Time{}, validationError("invalid defer time %q: empty", raw)
+	}
+
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, raw, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch value {
+	case "tomorrow":
+		return midnight.AddDate(0, 0, 1), nil
+	case "next-week":
+		return nextWeekday(midnight, time.Monday), nil
+	}
+	if name, ok := strings.CutPrefix(value, "next-"); ok {
+		if day, ok := parseWeekday(name); ok {
+			return nextWeekday(midnight, day), nil
+		}
+	}
+
+	if d, err := parseDeferDuration(value); err == nil {
+		return now.Add(d), nil
+	}
+	return time.Time{}, validationError("invalid defer time %q (want a timestamp, date, duration like 3d, tomorrow or next-monday)", raw)
+}
+
+// parseDeferDuration accepts Go durations plus whole-day (d) and week (w) units.
+func parseDeferDuration(value string) (time.Duration, error) {
+	var d time.Duration
+	var err error
+	switch {
+	case strings.HasSuffix(value, "d"), strings.HasSuffix(value, "w"):
+		unit := 24 * time.Hour
+		if strings.HasSuffix(value, "w") {
+			unit *= 7
+		}
+		var n int
+		n, err = strconv.Atoi(value[:len(value)-1])
+		d = time.Duration(n) * unit
+	default:
+		d, err = time.ParseDuration(value)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration %q must be positive", value)
+	}
+	return d, nil
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// nextWeekday returns the first midnight strictly after day-start midnight
+// that falls on day.
+func nextWeekday(midnight time.Time, day time.Weekday) time.Time {
+	ahead := (int(day) - int(midnight.Weekday()) + 7) % 7
+	if ahead == 0 {
+		ahead = 7
+	}
+	return midnight.AddDate(0, 0, ahead)
+}
@@ -0,0 +1,173 @@
+// ABOUTME: Tests deferral: parsing --until values, tl defer/undefer, automatic wake-up and list --deferred.
+// ABOUTME: Wake-up needs no event — a deferral whose time has passed reads as open to ready and claim.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func deferEventAt(t *testing.T, id string, until, ts time.Time) Event {
+	t.Helper()
+	data, err := json.Marshal(DeferEventData{Until: until})
+	require.NoError(t, err)
+	return Event{Type: EventDefer, ID: id, Timestamp: ts, Actor: "test", Data: data}
+}
+
+func TestParseDeferUntil(t *testing.T) {
+	// Wednesday afternoon.
+	now := time.Date(2026, 3, 4, 15, 30, 0, 0, time.UTC)
+	midnight := func(day int) time.Time { return time.Date(2026, 3, day, 0, 0, 0, 0, time.UTC) }
+
+	cases := map[string]time.Time{
+		"2026-03-10T09:00:00Z": time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC),
+		"2026-03-10":           midnight(10),
+		"2026-03-10T09:00":     time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC),
+		"90m":                  now.Add(90 * time.Minute),
+		"3d":                   now.Add(72 * time.Hour),
+		"2w":                   now.Add(14 * 24 * time.Hour),
+		"tomorrow":             midnight(5),
+		"next-monday":          midnight(9),
+		"next-wed":             midnight(11),
+		"Next-Friday":          midnight(6),
+		"next-week":            midnight(9),
+	}
+	for raw, want := range cases {
+		got, err := parseDeferUntil(raw, now)
+		require.NoError(t, err, raw)
+		assert.True(t, want.Equal(got), "%s: want %s, got %s", raw, want, got)
+	}
+
+	for _, raw := range []string{"", "soon", "next-funday", "-3d", "0h"} {
+		_, err := parseDeferUntil(raw, now)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestDeferredTaskWakesAutomatically(t *testing.T) {
+	ts := time.Now().Add(-time.Hour)
+	dir := seedCommandRepoWithEvents(t,
+		createIssueEvent(t, "tl-later", "Later", StatusOpen, 1, ts),
+		createIssueEvent(t, "tl-woken", "Woken", StatusOpen, 2, ts),
+		deferEventAt(t, "tl-later", time.Now().Add(48*time.Hour), ts),
+		deferEventAt(t, "tl-woken", time.Now().Add(-time.Minute), ts),
+	)
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.Equal(t, StatusDeferred, graph.Tasks["tl-woken"].Status)
+	ready := collectReadyIssues(graph, computeBlockedSet(graph), time.Now())
+	require.Len(t, ready, 1)
+	assert.Equal(t, "tl-woken", ready[0].ID)
+
+	reason := notReadyReason(graph, graph.Tasks["tl-later"], computeBlockedSet(graph), time.Now())
+	assert.Contains(t, reason, "deferred until ")
+
+	result, err := claimTask(dir, claimRequest{Agent: "alice", Filter: readyFilter{MaxPriority: -1}})
+	require.NoError(t, err)
+	assert.Equal(t, "tl-woken", result.Issue.ID)
+	assert.Equal(t, StatusInProgress, result.Issue.Status)
+	assert.Nil(t, result.Issue.DeferUntil)
+
+	_, err = claimTask(dir, claimRequest{ID: "tl-later", Agent: "alice", Filter: readyFilter{MaxPriority: -1}})
+	assert.ErrorContains(t, err, "is not open (status: deferred)")
+}
+
+func TestDeferredBlockerKeepsDependentOutOfReady(t *testing.T) {
+	ts := time.Now().Add(-time.Hour)
+	dir := seedCommandRepoWithEvents(t,
+		createIssueEvent(t, "tl-blocker", "Blocker", StatusOpen, 1, ts),
+		createIssueEvent(t, "tl-dependent", "Dependent", StatusOpen, 1, ts),
+		depAddEvent(t, "tl-dependent", "tl-blocker", DepBlocks, ts),
+		deferEventAt(t, "tl-blocker", time.Now().Add(72*time.Hour), ts),
+	)
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	blockedSet := computeBlockedSet(graph)
+	assert.True(t, blockedSet["tl-dependent"])
+	assert.Empty(t, collectReadyIssues(graph, blockedSet, time.Now()))
+	assert.Equal(t, "blocked by tl-blocker", notReadyReason(graph, graph.Tasks["tl-dependent"], blockedSet, time.Now()))
+}
+
+func TestDeferAndUndeferCommands(t *testing.T) {
+	dir := seedCommandRepoWithEvents(t,
+		createIssueEvent(t, "tl-a", "Task A", StatusOpen, 1, time.Now().Add(-time.Hour)),
+		createIssueEvent(t, "tl-done", "Done", StatusClosed, 1, time.Now().Add(-time.Hour)),
+	)
+	setCommandGlobals(t, dir, false)
+	prev := deferUntil
+	t.Cleanup(func() { deferUntil = prev })
+
+	deferUntil = ""
+	assert.ErrorContains(t, runDefer(newTestCommand(), []string{"tl-a"}), "--until is required")
+	deferUntil = "2020-01-01"
+	assert.ErrorContains(t, runDefer(newTestCommand(), []string{"tl-a"}), "not in the future")
+
+	deferUntil = "3d"
+	cmd := newTestCommand()
+	require.NoError(t, runDefer(cmd, []string{"tl-a"}))
+	assert.Contains(t, cmd.OutOrStdout().(*bytes.Buffer).String(), "Deferred tl-a until ")
+	assert.Error(t, runDefer(newTestCommand(), []string{"tl-done"}), "closed tasks cannot be deferred")
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	issue := graph.Tasks["tl-a"]
+	assert.Equal(t, StatusDeferred, issue.Status)
+	require.NotNil(t, issue.DeferUntil)
+	assert.WithinDuration(t, time.Now().Add(72*time.Hour), *issue.DeferUntil, time.Minute)
+	assert.Empty(t, collectReadyIssues(graph, computeBlockedSet(graph), time.Now()))
+
+	cmd = newTestCommand()
+	require.NoError(t, runUndefer(cmd, []string{"tl-a"}))
+	assert.Equal(t, "Undeferred tl-a\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+	assert.ErrorContains(t, runUndefer(newTestCommand(), []string{"tl-a"}), "is not deferred")
+
+	graph, err = loadGraph(dir)
+	require.NoError(t, err)
+	assert.Equal(t, StatusOpen, graph.Tasks["tl-a"].Status)
+	assert.Nil(t, graph.Tasks["tl-a"].DeferUntil)
+}
+
+func TestCreateEventCarriesDeferUntil(t *testing.T) {
+	until := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	data, err := json.Marshal(CreateEventData{Title: "Imported", Status: string(StatusOpen), DeferUntil: &until})
+	require.NoError(t, err)
+	graph, err := replayEvents([]Event{{Type: EventCreate, ID: "tl-imp", Timestamp: until.Add(-time.Hour), Actor: "test", Data: data}})
+	require.NoError(t, err)
+	require.NotNil(t, graph.Tasks["tl-imp"].DeferUntil)
+	assert.True(t, until.Equal(*graph.Tasks["tl-imp"].DeferUntil))
+}
+
+func TestListDeferredShowsWakeUpTimes(t *testing.T) {
+	ts := time.Now().Add(-time.Hour)
+	soon := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	later := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+	past := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	dir := seedCommandRepoWithEvents(t,
+		createIssueEvent(t, "tl-open", "Open", StatusOpen, 0, ts),
+		createIssueEvent(t, "tl-later", "Later", StatusOpen, 0, ts),
+		createIssueEvent(t, "tl-soon", "Soon", StatusOpen, 3, ts),
+		createIssueEvent(t, "tl-past", "Past", StatusOpen, 1, ts),
+		deferEventAt(t, "tl-later", later, ts),
+		deferEventAt(t, "tl-soon", soon, ts),
+		deferEventAt(t, "tl-past", past, ts),
+	)
+	resetListGlobals(dir)
+	listDeferred = true
+	t.Cleanup(func() { resetListGlobals("") })
+
+	cmd := newTestCommand()
+	require.NoError(t, runList(cmd, nil))
+	assert.Equal(t,
+		"tl-past [deferred] P1 Past (woke "+past.Format(time.RFC3339)+")\n"+
+			"tl-soon [deferred] P3 Soon (wakes "+soon.Format(time.RFC3339)+")\n"+
+			"tl-later [deferred] P0 Later (wakes "+later.Format(time.RFC3339)+")\n",
+		cmd.OutOrStdout().(*bytes.Buffer).String())
+}
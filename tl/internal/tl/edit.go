@@ -0,0 +1,273 @@
+// ABOUTME: Editable Markdown form of an issue for `tl edit`: YAML front matter plus the description as the body.
+// ABOUTME: Parses an edited document back and diffs it against the issue into the minimal update and dep events.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const editFrontMatterDelim = "---"
+
+// editFrontMatter is the YAML header of the edit document. Deps maps a
+// dependency type to the IDs this issue depends on with that type.
+type editFrontMatter struct {
+	Title    string                      `yaml:"title"`
+	Status   Status                      `yaml:"status"`
+	Priority int                         `yaml:"priority"`
+	Type     IssueType                   `yaml:"type"`
+	Labels   []string                    `yaml:"labels"`
+	Assignee string                      `yaml:"assignee"`
+	Deps     map[DependencyType][]string `yaml:"deps"`
+}
+
+// editDocument is an issue as the user edits it.
+type editDocument struct {
+	editFrontMatter
+	Description string
+}
+
+func newEditDocument(issue *Issue) editDocument {
+	doc := editDocument{
+		editFrontMatter: editFrontMatter{
+			Title:    issue.Title,
+			Status:   issue.Status,
+			Priority: issue.Priority,
+			Type:     issue.IssueType,
+			Labels:   append([]string{}, issue.Labels...),
+			Assignee: issue.Assignee,
+			Deps:     make(map[DependencyType][]string),
+		},
+		Description: issue.Description,
+	}
+	for _, dep := range issue.Dependencies {
+		if dep != nil {
+			doc.Deps[dep.Type] = append(doc.Deps[dep.Type], dep.DependsOnID)
+		}
+	}
+	for depType := range doc.Deps {
+		sort.Strings(doc.Deps[depType])
+	}
+	return doc
+}
+
+// render writes the document as Markdown with YAML front matter.
+func (doc editDocument) render() ([]byte, error) {
+	header, err := yaml.Marshal(doc.editFrontMatter)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s\n%s%s\n\n", editFrontMatterDelim, header, editFrontMatterDelim)
+	if description := strings.TrimSpace(doc.Description); description != "" {
+		buf.WriteString(description)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// parseEditDocument reads an edited document back. Unknown front matter keys
+// are rejected so a typo is not silently dropped.
+func parseEditDocument(data []byte) (editDocument, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, editFrontMatterDelim+"\n") {
+		return editDocument{}, validationError("edit document must start with a %q front matter line", editFrontMatterDelim)
+	}
+	rest := text[len(editFrontMatterDelim)+1:]
+	header, body, ok := strings.Cut(rest, "\n"+editFrontMatterDelim+"\n")
+	if !ok {
+		header, ok = strings.CutSuffix(rest, "\n"+editFrontMatterDelim)
+		if !ok {
+			return editDocument{}, validationError("edit document front matter is not closed with %q", editFrontMatterDelim)
+		}
+	}
+
+	var doc editDocument
+	decoder := yaml.NewDecoder(strings.NewReader(header))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&doc.editFrontMatter); err != nil {
+		return editDocument{}, validationError("edit document front matter: %v", err)
+	}
+	doc.Description = strings.TrimSpace(body)
+	return doc, doc.validate()
+}
+
+func (doc editDocument) validate() error {
+	if strings.TrimSpace(doc.Title) == "" {
+		return validationError("title must not be empty")
+	}
+	if !doc.Status.IsValid() {
+		return validationError("unknown status %q", doc.Status)
+	}
+	if doc.Priority < 0 {
+		return validationError("priority must not be negative")
+	}
+	for depType, ids := range doc.Deps {
+		if !depType.IsValid() {
+			return validationError("unknown dependency type %q (want one of: %s)", depType, strings.Join(knownDependencyTypes(), ", "))
+		}
+		for _, id := range ids {
+			if strings.TrimSpace(id) == "" {
+				return validationError("empty %s dependency ID", depType)
+			}
+		}
+	}
+	return nil
+}
+
+// editChange holds the events an edit produces and a short description of
+// each change for the command output.
+type editChange struct {
+	Events  []Event
+	Summary []string
+	// Close asks the caller to close the issue through closeIssue once
+	// Events are applied, so epic guards and auto-close apply to edits too.
+	Close bool
+}
+
+// diffEdit turns the difference between the issue and the edited document
+// into events: one update carrying only the changed fields, then a dep_remove
+// or dep_add per changed edge. Edges keep their conditions unless their type
+// changes. The checks mirror tl update and tl dep add; checkTarget vets each
+// new dependency target as tl dep add would.
+func diffEdit(graph *Graph, issue *Issue, doc editDocument, wip WIPConfig, now time.Time, checkTarget func(id string) error) (editChange, error) {
+	var change editChange
+	fields := make(map[string]json.RawMessage)
+	var names []string
+	set := func(name string, value any) error {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		fields[name] = raw
+		names = append(names, name)
+		return nil
+	}
+
+	current := newEditDocument(issue)
+	if doc.Title != current.Title {
+		if err := set("title", doc.Title); err != nil {
+			return change, err
+		}
+	}
+	if doc.Status != current.Status {
+		from := issue.Status
+		if deferLapsed(issue, now) {
+			from = StatusOpen
+		}
+		if err := validateTransition(from, doc.Status); err != nil {
+			return change, err
+		}
+		if doc.Status == StatusClosed {
+			// The close itself is left to the caller's shared close path.
+			change.Close = true
+			names = append(names, "status")
+		} else if err := set("status", doc.Status); err != nil {
+			return change, err
+		}
+	}
+	if doc.Status == StatusInProgress && (doc.Status != current.Status || doc.Assignee != current.Assignee) {
+		// Starting work, or handing in-progress work to someone else, takes a WIP slot.
+		if err := checkWIP(graph, wip, issue, doc.Assignee, now); err != nil {
+			return change, err
+		}
+	}
+	if doc.Priority != current.Priority {
+		if err := set("priority", doc.Priority); err != nil {
+			return change, err
+		}
+	}
+	if doc.Type != current.Type {
+		if err := set("issue_type", doc.Type); err != nil {
+			return change, err
+		}
+	}
+	if !stringSlicesEqual(doc.Labels, current.Labels) {
+		if err := set("labels", doc.Labels); err != nil {
+			return change, err
+		}
+	}
+	if doc.Assignee != current.Assignee {
+		if err := set("assignee", doc.Assignee); err != nil {
+			return change, err
+		}
+	}
+	if doc.Description != strings.TrimSpace(current.Description) {
+		if err := set("description", doc.Description); err != nil {
+			return change, err
+		}
+	}
+	if len(fields) > 0 {
+		evt, err := newEvent(EventUpdate, issue.ID, UpdateEventData{Fields: fields})
+		if err != nil {
+			return change, err
+		}
+		change.Events = append(change.Events, evt)
+	}
+	change.Summary = append(change.Summary, names...)
+
+	wanted := make(map[string]DependencyType)
+	for depType, ids := range doc.Deps {
+		for _, id := range ids {
+			if previous, dup := wanted[id]; dup && previous != depType {
+				return change, validationError("%s is listed as both %s and %s", id, previous, depType)
+			}
+			wanted[id] = depType
+		}
+	}
+
+	for _, dep := range issue.Dependencies {
+		if dep == nil {
+			continue
+		}
+		if _, keep := wanted[dep.DependsOnID]; keep {
+			continue
+		}
+		evt, err := newEvent(EventDepRemove, issue.ID, DepRemoveEventData{DependsOnID: dep.DependsOnID})
+		if err != nil {
+			return change, err
+		}
+		change.Events = append(change.Events, evt)
+		change.Summary = append(change.Summary, "-"+dep.DependsOnID)
+	}
+
+	targets := make([]string, 0, len(wanted))
+	for id := range wanted {
+		targets = append(targets, id)
+	}
+	sort.Strings(targets)
+	for _, id := range targets {
+		depType := wanted[id]
+		existing := findDependency(issue, id)
+		if existing != nil && existing.Type == depType {
+			continue
+		}
+		if id == issue.ID {
+			return change, validationError("cannot depend on self")
+		}
+		if existing == nil {
+			if err := checkTarget(id); err != nil {
+				return change, err
+			}
+		}
+		if needsCycleCheck(existing, depType) {
+			if path := cyclePath(graph, issue.ID, id); path != nil {
+				return change, &CycleError{Path: path}
+			}
+		}
+		evt, err := newEvent(EventDepAdd, issue.ID, DepAddEventData{DependsOnID: id, DepType: string(depType)})
+		if err != nil {
+			return change, err
+		}
+		change.Events = append(change.Events, evt)
+		change.Summary = append(change.Summary, fmt.Sprintf("+%s %s", depType, id))
+	}
+	return change, nil
+}
@@ -0,0 +1,253 @@
+// ABOUTME: Tests `tl edit`: the front matter document round trip and the minimal events an edit appends.
+// ABOUTME: Covers rejected transitions, unknown keys, bad dep targets and edits that race a concurrent change.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setEditor replaces the editor with edit, which rewrites the document text.
+// The path of the last edited file is recorded in *lastPath.
+func setEditor(t *testing.T, lastPath *string, edit func(string) string) {
+	t.Helper()
+	previous := runEditor
+	runEditor = func(cmd *cobra.Command, path string) error {
+		*lastPath = path
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, []byte(edit(string(data))), 0644)
+	}
+	t.Cleanup(func() { runEditor = previous })
+}
+
+func seedEditRepo(t *testing.T) string {
+	t.Helper()
+	ts := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	return seedCommandRepoWithEvents(t,
+		labeledIssueEvent(t, "tl-a", "Fix login", TypeBug, 2, []string{"auth"}, ts),
+		createIssueEvent(t, "tl-b", "Schema", StatusOpen, 1, ts),
+		createIssueEvent(t, "tl-c", "Docs", StatusOpen, 3, ts),
+		createIssueEvent(t, "tl-d", "Follow-up", StatusOpen, 3, ts),
+		depAddEvent(t, "tl-a", "tl-b", DepBlocks, ts.Add(time.Minute)),
+		depAddEvent(t, "tl-a", "tl-c", DepRelated, ts.Add(time.Minute)),
+	)
+}
+
+func readEditEvents(t *testing.T, dir string) []Event {
+	t.Helper()
+	events, err := readEvents(filepath.Join(dir, eventsFileName))
+	require.NoError(t, err)
+	return events
+}
+
+func TestEditDocumentRoundTrip(t *testing.T) {
+	issue := &Issue{
+		ID: "tl-a", Title: "Fix login", Status: StatusOpen, Priority: 2, IssueType: TypeBug,
+		Labels: []string{"auth"}, Description: "Line one.\n\n---\n\nLine two.",
+		Dependencies: []*Dependency{
+			{IssueID: "tl-a", DependsOnID: "tl-c", Type: DepRelated},
+			{IssueID: "tl-a", DependsOnID: "tl-b", Type: DepBlocks},
+		},
+	}
+	data, err := newEditDocument(issue).render()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "---\ntitle: Fix login\n"))
+	assert.Contains(t, string(data), "deps:\n    blocks:\n        - tl-b\n    related:\n        - tl-c\n")
+
+	doc, err := parseEditDocument(data)
+	require.NoError(t, err)
+	assert.Equal(t, newEditDocument(issue), doc)
+
+	change, err := diffEdit(&Graph{Tasks: map[string]*Issue{"tl-a": issue}}, issue, doc, WIPConfig{}, time.Now(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, change.Events)
+}
+
+func TestDiffEditSkipsNilDependencies(t *testing.T) {
+	issue := &Issue{
+		ID: "tl-a", Title: "Task", Status: StatusOpen,
+		Dependencies: []*Dependency{nil, {IssueID: "tl-a", DependsOnID: "tl-b", Type: DepBlocks}},
+	}
+	doc := newEditDocument(issue)
+	doc.Deps = nil
+
+	change, err := diffEdit(&Graph{Tasks: map[string]*Issue{"tl-a": issue}}, issue, doc, WIPConfig{}, time.Now(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-tl-b"}, change.Summary)
+}
+
+func TestParseEditDocumentRejectsBadInput(t *testing.T) {
+	for name, text := range map[string]string{
+		"no front matter": "title: x\n",
+		"unclosed":        "---\ntitle: x\nstatus: open\n",
+		"unknown key":     "---\ntitle: x\nstatus: open\nowner: me\n---\n",
+		"empty title":     "---\ntitle: \" \"\nstatus: open\n---\n",
+		"bad status":      "---\ntitle: x\nstatus: done\n---\n",
+		"bad dep type":    "---\ntitle: x\nstatus: open\ndeps:\n  needs: [tl-b]\n---\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseEditDocument([]byte(text))
+			assert.ErrorIs(t, err, ErrValidation)
+		})
+	}
+}
+
+func TestEditAppendsMinimalEvents(t *testing.T) {
+	dir := seedEditRepo(t)
+	setCommandGlobals(t, dir, false)
+	var path string
+	setEditor(t, &path, func(text string) string {
+		text = strings.Replace(text, "title: Fix login", "title: Fix login redirect", 1)
+		text = strings.Replace(text, "    - auth\n", "    - auth\n    - web\n", 1)
+		text = strings.Replace(text, "    blocks:\n        - tl-b\n    related:\n        - tl-c\n",
+			"    blocks:\n        - tl-c\n    discovered-from:\n        - tl-d\n", 1)
+		return text + "Users land on /home.\n\nThey should return to the page they asked for.\n"
+	})
+	before := len(readEditEvents(t, dir))
+
+	cmd := newTestCommand()
+	require.NoError(t, runEdit(cmd, []string{"tl-a"}))
+	assert.Equal(t, "Edited tl-a: title, labels, description, -tl-b, +blocks tl-c, +discovered-from tl-d\n",
+		cmd.OutOrStdout().(*bytes.Buffer).String())
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "temp file is removed after a successful edit")
+
+	events := readEditEvents(t, dir)[before:]
+	require.Len(t, events, 4)
+	assert.Equal(t, EventUpdate, events[0].Type)
+	var update UpdateEventData
+	require.NoError(t, json.Unmarshal(events[0].Data, &update))
+	assert.Len(t, update.Fields, 3)
+	assert.Equal(t, []string{EventDepRemove, EventDepAdd, EventDepAdd},
+		[]string{events[1].Type, events[2].Type, events[3].Type})
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	issue := graph.Tasks["tl-a"]
+	assert.Equal(t, "Fix login redirect", issue.Title)
+	assert.Equal(t, []string{"auth", "web"}, issue.Labels)
+	assert.Equal(t, "Users land on /home.\n\nThey should return to the page they asked for.", issue.Description)
+	assert.Equal(t, DepBlocks, findDependency(issue, "tl-c").Type)
+	assert.Equal(t, DepDiscoveredFrom, findDependency(issue, "tl-d").Type)
+	assert.Nil(t, findDependency(issue, "tl-b"))
+}
+
+func TestEditWithoutChanges(t *testing.T) {
+	dir := seedEditRepo(t)
+	setCommandGlobals(t, dir, false)
+	var path string
+	setEditor(t, &path, func(text string) string { return text })
+	before := len(readEditEvents(t, dir))
+
+	cmd := newTestCommand()
+	require.NoError(t, runEdit(cmd, []string{"tl-a"}))
+	assert.Equal(t, "No changes to tl-a\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+	assert.Len(t, readEditEvents(t, dir), before)
+}
+
+func TestEditRejectsInvalidChanges(t *testing.T) {
+	for name, tc := range map[string]struct {
+		edit func(string) string
+		want error
+	}{
+		"unknown key": {
+			edit: func(text string) string { return strings.Replace(text, "assignee:", "owner:", 1) },
+			want: ErrValidation,
+		},
+		"missing target": {
+			edit: func(text string) string { return strings.Replace(text, "- tl-c", "- tl-zzz", 1) },
+			want: ErrNotFound,
+		},
+		"self dependency": {
+			edit: func(text string) string { return strings.Replace(text, "- tl-c", "- tl-a", 1) },
+			want: ErrValidation,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := seedEditRepo(t)
+			setCommandGlobals(t, dir, false)
+			var path string
+			setEditor(t, &path, tc.edit)
+			before := len(readEditEvents(t, dir))
+
+			err := runEdit(newTestCommand(), []string{"tl-a"})
+			require.ErrorIs(t, err, tc.want)
+			assert.Contains(t, err.Error(), path)
+			_, statErr := os.Stat(path)
+			assert.NoError(t, statErr, "rejected edit is kept")
+			os.Remove(path)
+			assert.Len(t, readEditEvents(t, dir), before)
+		})
+	}
+}
+
+func TestEditChecksDependencyTargetsLikeDepAdd(t *testing.T) {
+	dir := seedEditRepo(t)
+	setCommandGlobals(t, dir, false)
+	prev := editAllowDangling
+	t.Cleanup(func() { editAllowDangling = prev })
+	var path string
+	setEditor(t, &path, func(text string) string { return strings.Replace(text, "- tl-c", "- db:tl-0001", 1) })
+
+	err := runEdit(newTestCommand(), []string{"tl-a"})
+	require.ErrorIs(t, err, ErrValidation)
+	assert.Contains(t, err.Error(), `remote "db" is not configured`)
+	os.Remove(path)
+
+	setEditor(t, &path, func(text string) string { return strings.Replace(text, "- tl-c", "- tl-later", 1) })
+	editAllowDangling = true
+	require.NoError(t, runEdit(newTestCommand(), []string{"tl-a"}))
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.NotNil(t, findDependency(graph.Tasks["tl-a"], "tl-later"))
+}
+
+func TestEditRejectsInvalidTransition(t *testing.T) {
+	dir := seedCommandRepoWithEvents(t,
+		createIssueEvent(t, "tl-a", "Task", StatusOpen, 1, time.Now().UTC()),
+		statusUpdateEvent(t, "tl-a", StatusDeferred, time.Now().UTC()),
+	)
+	setCommandGlobals(t, dir, false)
+	var path string
+	setEditor(t, &path, func(text string) string { return strings.Replace(text, "status: deferred", "status: closed", 1) })
+	t.Cleanup(func() { os.Remove(path) })
+
+	err := runEdit(newTestCommand(), []string{"tl-a"})
+	require.ErrorIs(t, err, ErrInvalidTransition)
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.Equal(t, StatusDeferred, graph.Tasks["tl-a"].Status)
+}
+
+func TestEditRejectsConcurrentChange(t *testing.T) {
+	dir := seedEditRepo(t)
+	setCommandGlobals(t, dir, false)
+	var path string
+	setEditor(t, &path, func(text string) string {
+		// Someone else updates the issue while the editor is open.
+		require.NoError(t, runNote(newTestCommand(), []string{"tl-a", "meanwhile"}))
+		return strings.Replace(text, "priority: 2", "priority: 0", 1)
+	})
+	t.Cleanup(func() { os.Remove(path) })
+
+	err := runEdit(newTestCommand(), []string{"tl-a"})
+	require.ErrorIs(t, err, ErrConflict)
+	assert.Contains(t, err.Error(), path)
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, graph.Tasks["tl-a"].Priority)
+}
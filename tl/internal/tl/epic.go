@@ -0,0 +1,262 @@
+// ABOUTME: Epic rollups over parent-child descendants: progress counts and close helpers.
+// ABOUTME: Supports progress display, close guards, cascade close, and config-driven auto-close.
+
+package tl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// autoCloseActor is the actor recorded on events tl emits on its own behalf.
+const autoCloseActor = "tl"
+
+type epicProgress struct {
+	Total    int            `json:"total"`
+	Closed   int            `json:"closed"`
+	Percent  int            `json:"percent"`
+	ByStatus map[Status]int `json:"by_status"`
+}
+
+// issueWithProgress decorates an epic's JSON with its rollup.
+type issueWithProgress struct {
+	*Issue
+	Progress *epicProgress `json:"progress,omitempty"`
+}
+
+func withProgress(graph *Graph, issue *Issue) issueWithProgress {
+	row := issueWithProgress{Issue: issue}
+	if issue.IssueType == TypeEpic {
+		progress := computeEpicProgress(graph, issue)
+		row.Progress = &progress
+	}
+	return row
+}
+
+func computeEpicProgress(graph *Graph, epic *Issue) epicProgress {
+	progress := epicProgress{ByStatus: make(map[Status]int)}
+	for id := range descendantIDs(graph, epic.ID) {
+		issue, ok := graph.Tasks[id]
+		if !ok {
+			continue
+		}
+		progress.Total++
+		progress.ByStatus[issue.Status]++
+		if issue.Status == StatusClosed {
+			progress.Closed++
+		}
+	}
+
+	switch {
+	case progress.Total > 0:
+		progress.Percent = progress.Closed * 100 / progress.Total
+	case epic.Status == StatusClosed:
+		progress.Percent = 100
+	}
+	return progress
+}
+
+// formatStatusCounts renders counts in a stable order, e.g. "closed=2 open=1".
+func formatStatusCounts(counts map[Status]int) string {
+	if len(counts) == 0 {
+		return "none"
+	}
+	statuses := make([]string, 0, len(counts))
+	for status := range counts {
+		statuses = append(statuses, string(status))
+	}
+	sort.Strings(statuses)
+
+	parts := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		parts = append(parts, fmt.Sprintf("%s=%d", status, counts[Status(status)]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// openDescendants returns the non-closed descendants of id, deepest first so a
+// cascade closes children before their parents.
+func openDescendants(graph *Graph, id string) []*Issue {
+	depth := make(map[string]int)
+	var walk func(parentID string, level int)
+	walk = func(parentID string, level int) {
+		for _, childID := range childIDs(graph, parentID) {
+			if _, seen := depth[childID]; seen || childID == id {
+				continue
+			}
+			depth[childID] = level
+			walk(childID, level+1)
+		}
+	}
+	walk(id, 1)
+
+	var open []*Issue
+	for childID := range depth {
+		if issue, ok := graph.Tasks[childID]; ok && issue.Status != StatusClosed {
+			open = append(open, issue)
+		}
+	}
+	sort.Slice(open, func(i, j int) bool {
+		if depth[open[i].ID] != depth[open[j].ID] {
+			return depth[open[i].ID] > depth[open[j].ID]
+		}
+		return open[i].ID < open[j].ID
+	})
+	return open
+}
+
+// awaitsChildren reports whether issue is an epic with unfinished
+// descendants. Such an epic is a container rather than workable: it stays out
+// of the ready queue, and cannot close, until its children are closed.
+func awaitsChildren(graph *Graph, issue *Issue) bool {
+	return issue.IssueType == TypeEpic && len(openDescendants(graph, issue.ID)) > 0
+}
+
+// parentIDs returns the targets of the issue's parent-child dependencies.
+func parentIDs(issue *Issue) []string {
+	var parents []string
+	for _, dep := range issue.Dependencies {
+		if dep != nil && dep.Type == DepParentChild {
+			parents = append(parents, dep.DependsOnID)
+		}
+	}
+	return parents
+}
+
+// closeResult is what closing an issue appended, in log order: the
+// descendants a cascade closed, the issue itself, then the epics that
+// auto-closed behind it.
+type closeResult struct {
+	Events     []Event
+	Cascaded   []string
+	AutoClosed []string
+}
+
+// closeIssue is the close path shared by tl close, update and edit. It refuses
+// to close an epic with open descendants unless cascade closes them first,
+// applies every close to graph, and auto-closes finished epic ancestors when
+// the config asks for it. The caller validates issue's own transition.
+func closeIssue(graph *Graph, cfg Config, issue *Issue, reason string, cascade bool) (closeResult, error) {
+	var result closeResult
+	if issue.IssueType == TypeEpic && issue.Status != StatusClosed {
+		open := openDescendants(graph, issue.ID)
+		if len(open) > 0 && !cascade {
+			ids := make([]string, 0, len(open))
+			for _, child := range open {
+				ids = append(ids, child.ID)
+			}
+			return result, conflictError("epic %s has %d open children (%s); use tl close --cascade to close them", issue.ID, len(open), strings.Join(ids, " "))
+		}
+		// Report every child the cascade cannot close before closing any.
+		var stuck []string
+		for _, child := range open {
+			if !cascadeClosable(child) {
+				stuck = append(stuck, fmt.Sprintf("%s (%s)", child.ID, pinnedOrStatus(child)))
+			}
+		}
+		if len(stuck) > 0 {
+			return result, conflictError("cannot cascade close epic %s: %s cannot be closed; remove them from the epic first", issue.ID, strings.Join(stuck, ", "))
+		}
+		for _, child := range open {
+			evt, err := newEvent(EventClose, child.ID, CloseEventData{Reason: reason})
+			if err != nil {
+				return result, err
+			}
+			if err := graph.applyEvent(evt); err != nil {
+				return result, err
+			}
+			result.Events = append(result.Events, evt)
+			result.Cascaded = append(result.Cascaded, child.ID)
+		}
+	}
+
+	evt, err := newEvent(EventClose, issue.ID, CloseEventData{Reason: reason})
+	if err != nil {
+		return result, err
+	}
+	if err := graph.applyEvent(evt); err != nil {
+		return result, err
+	}
+	result.Events = append(result.Events, evt)
+
+	if cfg.Epics.AutoClose {
+		closeEvents, err := autoCloseEpics(graph, issue.ID)
+		if err != nil {
+			return result, err
+		}
+		for _, closeEvt := range closeEvents {
+			result.AutoClosed = append(result.AutoClosed, closeEvt.ID)
+		}
+		result.Events = append(result.Events, closeEvents...)
+	}
+	return result, nil
+}
+
+// cascadeClosable reports whether a cascade may close child. A deferred child
+// is postponed work the epic no longer needs, so it closes with the epic;
+// pinned and hooked children are held open on purpose and are left to the user.
+func cascadeClosable(child *Issue) bool {
+	if child.Pinned {
+		return false
+	}
+	from := child.Status
+	if from == StatusDeferred {
+		from = StatusOpen
+	}
+	return validateTransition(from, StatusClosed) == nil
+}
+
+// pinnedOrStatus names why a child is held open, for cascade errors.
+func pinnedOrStatus(child *Issue) string {
+	if child.Pinned {
+		return "pinned"
+	}
+	return string(child.Status)
+}
+
+// autoCloseEpics closes every epic ancestor of closedID whose descendants are
+// now all closed, applying each close to graph. The caller must already have
+// applied the close of closedID.
+func autoCloseEpics(graph *Graph, closedID string) ([]Event, error) {
+	var events []Event
+	queue := []string{closedID}
+	visited := map[string]bool{}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		issue, ok := graph.Tasks[current]
+		if !ok {
+			continue
+		}
+		for _, parentID := range parentIDs(issue) {
+			if visited[parentID] {
+				continue
+			}
+			visited[parentID] = true
+			parent, ok := graph.Tasks[parentID]
+			if !ok || parent.IssueType != TypeEpic || parent.Status == StatusClosed {
+				continue
+			}
+			if len(openDescendants(graph, parentID)) > 0 {
+				continue
+			}
+			if err := validateTransition(parent.Status, StatusClosed); err != nil {
+				continue
+			}
+
+			reason := "all children closed"
+			evt, err := newEvent(EventClose, parentID, CloseEventData{Reason: reason})
+			if err != nil {
+				return nil, err
+			}
+			evt.Actor = autoCloseActor
+			if err := graph.applyEvent(evt); err != nil {
+				return nil, err
+			}
+			events = append(events, evt)
+			queue = append(queue, parentID)
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,248 @@
+// ABOUTME: Tests epic rollups, ready children, close guards, cascade close and config-driven auto-close.
+// ABOUTME: Drives runClose/runUpdate/runEdit/runShow/runList against replayed event logs with parent-child edges.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seedEpicRepo(t *testing.T) string {
+	t.Helper()
+	ts := time.Date(2026, 2, 4, 9, 0, 0, 0, time.UTC)
+	epic, err := json.Marshal(CreateEventData{Title: "Release", Status: string(StatusOpen), IssueType: string(TypeEpic)})
+	require.NoError(t, err)
+	return seedCommandRepoWithEvents(
+		t,
+		Event{Type: EventCreate, ID: "tl-epic", Timestamp: ts, Actor: "test", Data: epic},
+		createIssueEvent(t, "tl-one", "One", StatusOpen, 1, ts.Add(time.Minute)),
+		createIssueEvent(t, "tl-two", "Two", StatusOpen, 1, ts.Add(2*time.Minute)),
+		createIssueEvent(t, "tl-three", "Three", StatusOpen, 1, ts.Add(3*time.Minute)),
+		depAddEvent(t, "tl-one", "tl-epic", DepParentChild, ts.Add(4*time.Minute)),
+		depAddEvent(t, "tl-two", "tl-epic", DepParentChild, ts.Add(5*time.Minute)),
+		depAddEvent(t, "tl-three", "tl-two", DepParentChild, ts.Add(6*time.Minute)),
+		closeIssueEvent(t, "tl-one", "done", ts.Add(7*time.Minute)),
+	)
+}
+
+func newCloseCommand(t *testing.T, flags map[string]string) *cobra.Command {
+	t.Helper()
+	cmd := newTestCommand()
+	cmd.Flags().String("reason", "", "")
+	cmd.Flags().Bool("cascade", false, "")
+	for name, value := range flags {
+		require.NoError(t, cmd.Flags().Set(name, value))
+	}
+	return cmd
+}
+
+func TestComputeEpicProgressCountsDescendants(t *testing.T) {
+	graph, err := loadGraph(seedEpicRepo(t))
+	require.NoError(t, err)
+
+	progress := computeEpicProgress(graph, graph.Tasks["tl-epic"])
+	assert.Equal(t, 3, progress.Total)
+	assert.Equal(t, 1, progress.Closed)
+	assert.Equal(t, 33, progress.Percent)
+	assert.Equal(t, map[Status]int{StatusClosed: 1, StatusOpen: 2}, progress.ByStatus)
+}
+
+func TestShowAndListEpicProgress(t *testing.T) {
+	dir := seedEpicRepo(t)
+
+	setCommandGlobals(t, dir, false)
+	cmd := newTestCommand()
+	require.NoError(t, runShow(cmd, []string{"tl-epic"}))
+	assert.Contains(t, cmd.OutOrStdout().(*bytes.Buffer).String(), "progress:     1/3 closed (33%) closed=1 open=2\n")
+
+	resetListGlobals(dir)
+	listType = string(TypeEpic)
+	t.Cleanup(func() { resetListGlobals("") })
+	cmd = newTestCommand()
+	require.NoError(t, runList(cmd, nil))
+	assert.Equal(t, "tl-epic [open] P0 Release (33%, 1/3 closed; closed=1 open=2)\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	setCommandGlobals(t, dir, true)
+	cmd = newTestCommand()
+	require.NoError(t, runShow(cmd, []string{"tl-epic"}))
+	var shown issueWithProgress
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(cmd.OutOrStdout().(*bytes.Buffer).Bytes()), &shown))
+	require.NotNil(t, shown.Progress)
+	assert.Equal(t, "tl-epic", shown.ID)
+	assert.Equal(t, 33, shown.Progress.Percent)
+}
+
+func TestOpenEpicChildrenAreReady(t *testing.T) {
+	dir := seedEpicRepo(t)
+	setReadyGlobals(t, dir, false)
+	ready := func() string {
+		cmd := newTestCommand()
+		require.NoError(t, runReady(cmd, nil))
+		return cmd.OutOrStdout().(*bytes.Buffer).String()
+	}
+
+	assert.Equal(t, "tl-two P1 Two\n", ready(), "the epic's child is ready; tl-three waits for its open task parent and the epic for its children")
+
+	require.NoError(t, runClose(newCloseCommand(t, nil), []string{"tl-three"}))
+	assert.Equal(t, "tl-two P1 Two\n", ready())
+	require.NoError(t, runClose(newCloseCommand(t, nil), []string{"tl-two"}))
+	assert.Equal(t, "tl-epic P0 Release\n", ready(), "an epic whose children are done is ready to close")
+	require.NoError(t, runClose(newCloseCommand(t, nil), []string{"tl-epic"}))
+}
+
+func TestCloseEpicRefusesWithOpenChildren(t *testing.T) {
+	dir := seedEpicRepo(t)
+	setCommandGlobals(t, dir, false)
+
+	err := runClose(newCloseCommand(t, nil), []string{"tl-epic"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "epic tl-epic has 2 open children (tl-three tl-two)")
+
+	graph, loadErr := loadGraph(dir)
+	require.NoError(t, loadErr)
+	assert.Equal(t, StatusOpen, graph.Tasks["tl-epic"].Status)
+}
+
+func TestCloseEpicCascade(t *testing.T) {
+	dir := seedEpicRepo(t)
+	setCommandGlobals(t, dir, false)
+
+	cmd := newCloseCommand(t, map[string]string{"cascade": "true", "reason": "shipped"})
+	require.NoError(t, runClose(cmd, []string{"tl-epic"}))
+	assert.Equal(t, "Closed tl-three (cascade)\nClosed tl-two (cascade)\nClosed tl-epic\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	for _, id := range []string{"tl-epic", "tl-two", "tl-three"} {
+		assert.Equal(t, StatusClosed, graph.Tasks[id].Status, id)
+		assert.Equal(t, "shipped", graph.Tasks[id].CloseReason, id)
+	}
+}
+
+func TestCloseEpicCascadeHandlesHeldChildren(t *testing.T) {
+	dir := seedEpicRepo(t)
+	setCommandGlobals(t, dir, false)
+	setStatus := func(id string, status Status) {
+		require.NoError(t, mutate(dir, func(_ *Graph) ([]Event, error) {
+			return []Event{statusUpdateEvent(t, id, status, time.Now().UTC())}, nil
+		}))
+	}
+
+	setStatus("tl-three", StatusDeferred)
+	setStatus("tl-two", StatusPinned)
+	err := runClose(newCloseCommand(t, map[string]string{"cascade": "true"}), []string{"tl-epic"})
+	require.ErrorIs(t, err, ErrConflict)
+	assert.Contains(t, err.Error(), "tl-two (pinned) cannot be closed")
+	assert.NotContains(t, err.Error(), "tl-three", "a deferred child can be cascade-closed")
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.Equal(t, StatusDeferred, graph.Tasks["tl-three"].Status, "nothing closes when a child is held")
+
+	setStatus("tl-two", StatusOpen)
+	cmd := newCloseCommand(t, map[string]string{"cascade": "true"})
+	require.NoError(t, runClose(cmd, []string{"tl-epic"}))
+	assert.Equal(t, "Closed tl-three (cascade)\nClosed tl-two (cascade)\nClosed tl-epic\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+}
+
+func TestCloseLastChildAutoClosesEpicWhenConfigured(t *testing.T) {
+	dir := seedEpicRepo(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, configFileName), []byte("epics:\n  auto_close: true\n"), 0644))
+	setCommandGlobals(t, dir, false)
+
+	require.NoError(t, runClose(newCloseCommand(t, nil), []string{"tl-three"}))
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.Equal(t, StatusOpen, graph.Tasks["tl-epic"].Status, "tl-two still open")
+
+	cmd := newCloseCommand(t, nil)
+	require.NoError(t, runClose(cmd, []string{"tl-two"}))
+	assert.Equal(t, "Closed tl-two\nAuto-closed epic tl-epic\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	events, err := readEvents(filepath.Join(dir, eventsFileName))
+	require.NoError(t, err)
+	last := events[len(events)-1]
+	assert.Equal(t, EventClose, last.Type)
+	assert.Equal(t, "tl-epic", last.ID)
+	assert.Equal(t, autoCloseActor, last.Actor)
+}
+
+func TestCloseLastChildLeavesEpicOpenByDefault(t *testing.T) {
+	dir := seedEpicRepo(t)
+	setCommandGlobals(t, dir, false)
+
+	require.NoError(t, runClose(newCloseCommand(t, nil), []string{"tl-three"}))
+	require.NoError(t, runClose(newCloseCommand(t, nil), []string{"tl-two"}))
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.Equal(t, StatusOpen, graph.Tasks["tl-epic"].Status)
+}
+
+func newStatusUpdateCommand(t *testing.T, status Status) *cobra.Command {
+	t.Helper()
+	cmd := newTestCommand()
+	cmd.Flags().String("status", "", "")
+	require.NoError(t, cmd.Flags().Set("status", string(status)))
+	return cmd
+}
+
+func TestUpdateAndEditCannotCloseEpicWithOpenChildren(t *testing.T) {
+	dir := seedEpicRepo(t)
+	setCommandGlobals(t, dir, false)
+
+	err := runUpdate(newStatusUpdateCommand(t, StatusClosed), []string{"tl-epic"})
+	require.ErrorIs(t, err, ErrConflict)
+	assert.Contains(t, err.Error(), "epic tl-epic has 2 open children (tl-three tl-two)")
+
+	var path string
+	setEditor(t, &path, func(text string) string { return strings.Replace(text, "status: open", "status: closed", 1) })
+	t.Cleanup(func() { os.Remove(path) })
+	err = runEdit(newTestCommand(), []string{"tl-epic"})
+	require.ErrorIs(t, err, ErrConflict)
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.Equal(t, StatusOpen, graph.Tasks["tl-epic"].Status)
+}
+
+func TestUpdateAndEditClosingLastChildAutoCloseEpic(t *testing.T) {
+	for name, closeTwo := range map[string]func(t *testing.T) string{
+		"update": func(t *testing.T) string {
+			cmd := newStatusUpdateCommand(t, StatusClosed)
+			require.NoError(t, runUpdate(cmd, []string{"tl-two"}))
+			return cmd.OutOrStdout().(*bytes.Buffer).String()
+		},
+		"edit": func(t *testing.T) string {
+			var path string
+			setEditor(t, &path, func(text string) string { return strings.Replace(text, "status: open", "status: closed", 1) })
+			cmd := newTestCommand()
+			require.NoError(t, runEdit(cmd, []string{"tl-two"}))
+			return cmd.OutOrStdout().(*bytes.Buffer).String()
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := seedEpicRepo(t)
+			require.NoError(t, os.WriteFile(filepath.Join(dir, configFileName), []byte("epics:\n  auto_close: true\n"), 0644))
+			setCommandGlobals(t, dir, false)
+			require.NoError(t, runClose(newCloseCommand(t, nil), []string{"tl-three"}))
+
+			assert.Contains(t, strings.ToLower(closeTwo(t)), "auto-closed epic tl-epic")
+
+			graph, err := loadGraph(dir)
+			require.NoError(t, err)
+			assert.Equal(t, StatusClosed, graph.Tasks["tl-two"].Status)
+			assert.NotNil(t, graph.Tasks["tl-two"].ClosedAt)
+			assert.Equal(t, StatusClosed, graph.Tasks["tl-epic"].Status)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// ABOUTME: Error taxonomy — stable error codes and process exit codes, and the --json error envelope.
+// ABOUTME: Classifies errors by sentinel so agents can tell retryable lock contention from not-found, conflicts and bad input.
+
+package tl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// Exit codes. They are part of the CLI contract; never renumber them.
+const (
+	exitInternal     = 1
+	exitValidation   = 2
+	exitNothingReady = 3
+	exitNotFound     = 4
+	exitConflict     = 5
+	exitLockBusy     = 6
+)
+
+// Error codes reported in the JSON envelope.
+const (
+	codeInternal          = "internal"
+	codeValidation        = "validation"
+	codeNothingReady      = "nothing_ready"
+	codeNotFound          = "not_found"
+	codeNotInitialized    = "not_initialized"
+	codeConflict          = "conflict"
+	codeCycle             = "cycle"
+	codeInvalidTransition = "invalid_transition"
+	codeWIPLimit          = "wip_limit"
+	codeLockBusy          = "lock_busy"
+)
+
+// lockRetryAfterMS is the back-off suggested to callers that hit ErrLockBusy.
+const lockRetryAfterMS = 250
+
+// errorClasses maps sentinels to codes, most specific first.
+var errorClasses = []struct {
+	sentinel error
+	code     string
+	exit     int
+}{
+	{ErrLockBusy, codeLockBusy, exitLockBusy},
+	{ErrNothingReady, codeNothingReady, exitNothingReady},
+	{ErrNoTLDir, codeNotInitialized, exitNotFound},
+	{ErrNotFound, codeNotFound, exitNotFound},
+	{ErrCycle, codeCycle, exitConflict},
+	{ErrInvalidTransition, codeInvalidTransition, exitConflict},
+	{ErrWIPLimit, codeWIPLimit, exitConflict},
+	{ErrConflict, codeConflict, exitConflict},
+	{ErrValidation, codeValidation, exitValidation},
+}
+
+// classifiedError tags an error with a sentinel for classification while
+// keeping its message unchanged.
+type classifiedError struct {
+	err   error
+	class error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() []error {
+	return []error{e.err, e.class}
+}
+
+// validationError reports bad input: flags, arguments or config values.
+func validationError(format string, args ...any) error {
+	return &classifiedError{err: fmt.Errorf(format, args...), class: ErrValidation}
+}
+
+// conflictError reports a request that is well-formed but clashes with the
+// current state, such as claiming a task someone else holds.
+func conflictError(format string, args ...any) error {
+	return &classifiedError{err: fmt.Errorf(format, args...), class: ErrConflict}
+}
+
+// classifyError returns the stable code and exit code for err. Errors that
+// match no sentinel are internal.
+func classifyError(err error) (string, int) {
+	for _, class := range errorClasses {
+		if errors.Is(err, class.sentinel) {
+			return class.code, class.exit
+		}
+	}
+	return codeInternal, exitInternal
+}
+
+func exitCode(err error) int {
+	_, code := classifyError(err)
+	return code
+}
+
+// errorDetails returns machine-readable specifics for err, or nil.
+func errorDetails(err error) any {
+	var cycleErr *CycleError
+	var wipErr *WIPLimitError
+	switch {
+	case errors.As(err, &cycleErr):
+		return map[string]any{"path": cycleErr.Path}
+	case errors.As(err, &wipErr):
+		return wipErr
+	case errors.Is(err, ErrLockBusy):
+		return map[string]any{"retryable": true, "retry_after_ms": lockRetryAfterMS}
+	}
+	return nil
+}
+
+type errorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
+}
+
+// writeError reports err as `Error: ...` text, or with asJSON as
+// {"error":{"code","message","details"}}.
+func writeError(w io.Writer, err error, asJSON bool) {
+	if !asJSON {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return
+	}
+	code, _ := classifyError(err)
+	data, marshalErr := json.Marshal(struct {
+		Error errorBody `json:"error"`
+	}{errorBody{Code: code, Message: err.Error(), Details: errorDetails(err)}})
+	if marshalErr != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return
+	}
+	fmt.Fprintln(w, string(data))
+}
+
+// classifyUsageErrors marks flag-parsing and argument-count failures across
+// the command tree as validation errors.
+func classifyUsageErrors(cmd *cobra.Command) {
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &classifiedError{err: err, class: ErrValidation}
+	})
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(c *cobra.Command, a []string) error {
+			if err := args(c, a); err != nil {
+				return &classifiedError{err: err, class: ErrValidation}
+			}
+			return nil
+		}
+	}
+	for _, child := range cmd.Commands() {
+		classifyUsageErrors(child)
+	}
+}
@@ -0,0 +1,96 @@
+// ABOUTME: Tests the error taxonomy: stable codes, exit codes and the --json error envelope.
+// ABOUTME: Covers sentinel classification, message-preserving wrappers, details and usage-error classification.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		err  error
+		code string
+		exit int
+	}{
+		{ErrLockBusy, codeLockBusy, exitLockBusy},
+		{fmt.Errorf("append: %w", ErrLockBusy), codeLockBusy, exitLockBusy},
+		{ErrNothingReady, codeNothingReady, exitNothingReady},
+		{ErrNoTLDir, codeNotInitialized, exitNotFound},
+		{fmt.Errorf("%w: tl-x", ErrNotFound), codeNotFound, exitNotFound},
+		{&CycleError{Path: []string{"a", "b", "a"}}, codeCycle, exitConflict},
+		{validateTransition(StatusClosed, StatusInProgress), codeInvalidTransition, exitConflict},
+		{&WIPLimitError{Scope: wipScopeGlobal, Limit: 1, Current: 1}, codeWIPLimit, exitConflict},
+		{conflictError("task %s is taken", "tl-x"), codeConflict, exitConflict},
+		{validationError("bad flag"), codeValidation, exitValidation},
+		{errors.New("disk on fire"), codeInternal, exitInternal},
+	}
+	for _, tc := range cases {
+		code, exit := classifyError(tc.err)
+		assert.Equal(t, tc.code, code, tc.err.Error())
+		assert.Equal(t, tc.exit, exit, tc.err.Error())
+		assert.Equal(t, tc.exit, exitCode(tc.err))
+	}
+}
+
+func TestClassifiedErrorKeepsMessageAndCause(t *testing.T) {
+	err := validationError("invalid lease %q: %w", "x", io.ErrUnexpectedEOF)
+	assert.EqualError(t, err, `invalid lease "x": unexpected EOF`)
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	assert.EqualError(t, validateTransition(StatusClosed, StatusBlocked), "invalid transition: closed → blocked")
+}
+
+func TestWriteError(t *testing.T) {
+	var buf bytes.Buffer
+	writeError(&buf, fmt.Errorf("issue %q: %w", "tl-x", ErrNotFound), false)
+	assert.Equal(t, "Error: issue \"tl-x\": not found\n", buf.String())
+
+	buf.Reset()
+	writeError(&buf, fmt.Errorf("issue %q: %w", "tl-x", ErrNotFound), true)
+	assert.JSONEq(t, `{"error":{"code":"not_found","message":"issue \"tl-x\": not found"}}`, buf.String())
+
+	buf.Reset()
+	writeError(&buf, ErrLockBusy, true)
+	var payload struct {
+		Error struct {
+			Code    string `json:"code"`
+			Details struct {
+				Retryable    bool `json:"retryable"`
+				RetryAfterMS int  `json:"retry_after_ms"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &payload))
+	assert.Equal(t, codeLockBusy, payload.Error.Code)
+	assert.True(t, payload.Error.Details.Retryable)
+	assert.Equal(t, lockRetryAfterMS, payload.Error.Details.RetryAfterMS)
+}
+
+func TestClassifyUsageErrors(t *testing.T) {
+	root := &cobra.Command{Use: "tl"}
+	child := &cobra.Command{Use: "show", Args: cobra.ExactArgs(1), RunE: func(*cobra.Command, []string) error { return nil }}
+	child.Flags().Int("limit", 0, "")
+	root.AddCommand(child)
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SilenceErrors, root.SilenceUsage = true, true
+	classifyUsageErrors(root)
+
+	for _, args := range [][]string{{"show"}, {"show", "--limit", "many", "x"}, {"show", "a", "b"}} {
+		root.SetArgs(args)
+		err := root.Execute()
+		require.Error(t, err, args)
+		assert.Equal(t, exitValidation, exitCode(err), err.Error())
+	}
+}
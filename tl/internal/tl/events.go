@@ -20,6 +20,17 @@ const (
 	EventDepAdd    = "dep_add"
 	EventDepRemove = "dep_remove"
 	EventClaim     = "claim"
+
+	EventHeartbeat    = "heartbeat"
+	EventLeaseExpired = "lease_expired"
+	EventRelease      = "release"
+	EventHandoff      = "handoff"
+
+	EventDefer   = "defer"
+	EventUndefer = "undefer"
+
+	EventComment = "comment"
+	EventNote    = "note"
 )
 
 // Event is the base event written to events.jsonl
@@ -40,6 +51,9 @@ type CreateEventData struct {
 	IssueType   string                     `json:"issue_type,omitempty"`
 	Labels      []string                   `json:"labels,omitempty"`
 	Metadata    map[string]json.RawMessage `json:"metadata,omitempty"`
+	DeferUntil  *time.Time                 `json:"defer_until,omitempty"`
+	Notes       string                     `json:"notes,omitempty"`
+	SpecID      string                     `json:"spec_id,omitempty"`
 }
 
 // UpdateEventData is the typed data for update events
@@ -58,8 +72,9 @@ type ReopenEventData struct {
 
 // DepAddEventData is the typed data for dependency add events
 type DepAddEventData struct {
-	DependsOnID string `json:"depends_on_id"`
-	DepType     string `json:"dep_type"`
+	DependsOnID string          `json:"depends_on_id"`
+	DepType     string          `json:"dep_type"`
+	Metadata    json.RawMessage `json:"metadata,omitempty"`
 }
 
 // DepRemoveEventData is the typed data for dependency remove events
@@ -67,9 +82,66 @@ type DepRemoveEventData struct {
 	DependsOnID string `json:"depends_on_id"`
 }
 
-// ClaimEventData is the typed data for claim events
+// ClaimEventData is the typed data for claim events.
+// Lease is a Go duration; the lease expires that long after the event.
 type ClaimEventData struct {
 	Agent string `json:"agent"`
+	Lease string `json:"lease,omitempty"`
+}
+
+// HeartbeatEventData is the typed data for heartbeat events, which restart
+// the claim's lease from the event timestamp.
+type HeartbeatEventData struct {
+	Agent string `json:"agent"`
+	Lease string `json:"lease"`
+}
+
+// LeaseExpiredEventData is the typed data for lease_expired events, recorded
+// when a lapsed claim is taken back so the task can be claimed again.
+type LeaseExpiredEventData struct {
+	Agent     string    `json:"agent"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+// ReleaseEventData is the typed data for release events, which return a
+// claimed task to open and clear its assignee.
+type ReleaseEventData struct {
+	Agent string `json:"agent"`
+	Note  string `json:"note,omitempty"`
+}
+
+// HandoffEventData is the typed data for handoff events, recorded when an
+// agent takes over (steals) a task claimed by someone else.
+type HandoffEventData struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Reason string `json:"reason"`
+	Lease  string `json:"lease,omitempty"`
+}
+
+// DeferEventData is the typed data for defer events, which park a task
+// until Until; after that it is ready again without further events.
+type DeferEventData struct {
+	Until time.Time `json:"until"`
+}
+
+// UndeferEventData is the typed data for undefer events, which return a
+// deferred task to open immediately.
+type UndeferEventData struct {
+}
+
+// CommentEventData is the typed data for comment events. The event actor is
+// the comment's author.
+type CommentEventData struct {
+	CommentID int    `json:"comment_id"`
+	Text      string `json:"text"`
+	ReplyTo   int    `json:"reply_to,omitempty"`
+}
+
+// NoteEventData is the typed data for note events. Replay appends the text
+// to Issue.Notes as an entry stamped with the event's time and actor.
+type NoteEventData struct {
+	Text string `json:"text"`
 }
 
 // resolveActor returns the actor name from environment or git config
@@ -0,0 +1,88 @@
+// ABOUTME: Unblock impact analysis — what closing a task would make ready.
+// ABOUTME: Simulates a close on a cloned graph and diffs the blocked set and ready queue.
+
+package tl
+
+import (
+	"sort"
+	"time"
+)
+
+type impactReport struct {
+	ID           string         `json:"id"`
+	NewlyReady   []readyIssue   `json:"newly_ready"`
+	StillBlocked []blockedIssue `json:"still_blocked"`
+	Downstream   int            `json:"downstream"`
+}
+
+// computeCloseImpact reports what closing id would change, without touching graph.
+func computeCloseImpact(graph *Graph, id string, now time.Time) (impactReport, error) {
+	report := impactReport{ID: id, NewlyReady: []readyIssue{}, StillBlocked: []blockedIssue{}}
+
+	evt, err := newEvent(EventClose, id, CloseEventData{})
+	if err != nil {
+		return report, err
+	}
+	evt.Timestamp = now
+	simulated := cloneGraph(graph)
+	if err := simulated.applyEvent(evt); err != nil {
+		return report, err
+	}
+
+	report.NewlyReady = newlyReadyIssues(graph, simulated, now)
+
+	downstream := downstreamIDs(graph, id)
+	report.Downstream = len(downstream)
+
+	blockedAfter := computeBlockedSet(simulated)
+	for _, row := range collectBlockedIssues(simulated, blockedAfter) {
+		if downstream[row.ID] {
+			report.StillBlocked = append(report.StillBlocked, row)
+		}
+	}
+	return report, nil
+}
+
+// newlyReadyIssues lists issues in the ready queue of after that were not ready in before.
+func newlyReadyIssues(before, after *Graph, now time.Time) []readyIssue {
+	wasReady := make(map[string]bool)
+	for _, issue := range collectReadyIssues(before, computeBlockedSet(before), now) {
+		wasReady[issue.ID] = true
+	}
+
+	rows := []readyIssue{}
+	for _, issue := range collectReadyIssues(after, computeBlockedSet(after), now) {
+		if !wasReady[issue.ID] {
+			rows = append(rows, newReadyIssue(issue))
+		}
+	}
+	return rows
+}
+
+// downstreamIDs returns every issue that transitively depends on id through
+// ready-affecting edges.
+func downstreamIDs(graph *Graph, id string) map[string]bool {
+	out := make(map[string]bool)
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		dependents := append([]string(nil), graph.RDeps[current]...)
+		sort.Strings(dependents)
+		for _, dependentID := range dependents {
+			if out[dependentID] || dependentID == id {
+				continue
+			}
+			issue, ok := graph.Tasks[dependentID]
+			if !ok {
+				continue
+			}
+			if dep := findDependency(issue, current); dep == nil || !dep.Type.AffectsReadyWork() {
+				continue
+			}
+			out[dependentID] = true
+			queue = append(queue, dependentID)
+		}
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+// ABOUTME: Tests close-impact simulation and the newly-ready handoff printed by tl close.
+// ABOUTME: Verifies the original graph is untouched and downstream/still-blocked reporting.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seedImpactRepo(t *testing.T) string {
+	t.Helper()
+	ts := time.Date(2026, 2, 5, 9, 0, 0, 0, time.UTC)
+	return seedCommandRepoWithEvents(
+		t,
+		createIssueEvent(t, "tl-a", "A", StatusOpen, 0, ts),
+		createIssueEvent(t, "tl-b", "B", StatusOpen, 1, ts.Add(time.Minute)),
+		createIssueEvent(t, "tl-c", "C", StatusOpen, 1, ts.Add(2*time.Minute)),
+		createIssueEvent(t, "tl-d", "D", StatusOpen, 2, ts.Add(3*time.Minute)),
+		createIssueEvent(t, "tl-f", "F", StatusOpen, 2, ts.Add(4*time.Minute)),
+		depAddEvent(t, "tl-b", "tl-a", DepBlocks, ts.Add(5*time.Minute)),
+		depAddEvent(t, "tl-c", "tl-a", DepBlocks, ts.Add(6*time.Minute)),
+		depAddEvent(t, "tl-c", "tl-d", DepBlocks, ts.Add(7*time.Minute)),
+		depAddEvent(t, "tl-f", "tl-c", DepBlocks, ts.Add(8*time.Minute)),
+	)
+}
+
+func TestComputeCloseImpact(t *testing.T) {
+	graph, err := loadGraph(seedImpactRepo(t))
+	require.NoError(t, err)
+
+	report, err := computeCloseImpact(graph, "tl-a", time.Now())
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, report.Downstream)
+	require.Len(t, report.NewlyReady, 1)
+	assert.Equal(t, "tl-b", report.NewlyReady[0].ID)
+
+	require.Len(t, report.StillBlocked, 2)
+	assert.Equal(t, "tl-c", report.StillBlocked[0].ID)
+	assert.Equal(t, []string{"tl-d"}, report.StillBlocked[0].Blockers)
+	assert.Equal(t, "tl-f", report.StillBlocked[1].ID)
+
+	assert.Equal(t, StatusOpen, graph.Tasks["tl-a"].Status, "simulation must not mutate the graph")
+	assert.Len(t, graph.Tasks["tl-b"].Dependencies, 1)
+}
+
+func TestDepImpactCommandText(t *testing.T) {
+	setCommandGlobals(t, seedImpactRepo(t), false)
+	cmd := newTestCommand()
+	require.NoError(t, runDepImpact(cmd, []string{"tl-a"}))
+
+	assert.Equal(t,
+		"Closing tl-a affects 3 downstream tasks\n"+
+			"Newly ready (1):\n"+
+			"  tl-b P1 B\n"+
+			"Still blocked (2):\n"+
+			"  tl-c [open] C (blocked by: tl-d)\n"+
+			"  tl-f [open] F (blocked by: tl-c)\n",
+		cmd.OutOrStdout().(*bytes.Buffer).String())
+}
+
+func TestDepImpactUnknownIssue(t *testing.T) {
+	setCommandGlobals(t, seedImpactRepo(t), false)
+	err := runDepImpact(newTestCommand(), []string{"tl-missing"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestCloseReportsNewlyReady(t *testing.T) {
+	dir := seedImpactRepo(t)
+	setCommandGlobals(t, dir, false)
+
+	cmd := newCloseCommand(t, nil)
+	require.NoError(t, runClose(cmd, []string{"tl-a"}))
+	assert.Equal(t, "Closed tl-a\nNow ready: tl-b P1 B\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	setCommandGlobals(t, dir, true)
+	cmd = newCloseCommand(t, nil)
+	require.NoError(t, runClose(cmd, []string{"tl-d"}))
+
+	var out struct {
+		ID         string       `json:"id"`
+		Status     Status       `json:"status"`
+		NewlyReady []readyIssue `json:"newly_ready"`
+	}
+	require.NoError(t, json.Unmarshal(cmd.OutOrStdout().(*bytes.Buffer).Bytes(), &out))
+	assert.Equal(t, "tl-d", out.ID)
+	assert.Equal(t, StatusClosed, out.Status)
+	require.Len(t, out.NewlyReady, 1)
+	assert.Equal(t, "tl-c", out.NewlyReady[0].ID)
+}
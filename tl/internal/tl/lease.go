@@ -0,0 +1,40 @@
+// ABOUTME: Claim leases — a claim that is not renewed by heartbeat lapses back to open.
+// ABOUTME: Parses lease durations and decides whether an in-progress claim has expired.
+
+package tl
+
+import (
+	"time"
+)
+
+// parseLeaseDuration validates a --lease value such as "30m" or "2h".
+func parseLeaseDuration(raw string) (time.Duration, error) {
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, validationError("invalid lease %q: %w", raw, err)
+	}
+	if d <= 0 {
+		return 0, validationError("invalid lease %q: must be positive", raw)
+	}
+	return d, nil
+}
+
+// leaseFrom builds the lease a claim or heartbeat at start establishes.
+// Empty or unparseable durations yield no lease so replay never fails on them.
+func leaseFrom(raw string, start time.Time) *Lease {
+	if raw == "" {
+		return nil
+	}
+	d, err := parseLeaseDuration(raw)
+	if err != nil {
+		return nil
+	}
+	return &Lease{Duration: d.String(), ExpiresAt: start.Add(d)}
+}
+
+// leaseExpired reports whether issue is an in-progress claim whose lease has
+// lapsed at now. Such a task is treated as open: it shows up as ready and can
+// be claimed again.
+func leaseExpired(issue *Issue, now time.Time) bool {
+	return issue.Status == StatusInProgress && issue.Lease != nil && !issue.Lease.ExpiresAt.After(now)
+}
@@ -0,0 +1,191 @@
+// ABOUTME: Tests claim leases: --lease on claim and re-claim, tl heartbeat renewal, and expiry handling.
+// ABOUTME: Expired leases must surface as ready, be reclaimable with a lease_expired event, and list as stale.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func claimEventAt(t *testing.T, id, agent, lease string, ts time.Time) Event {
+	t.Helper()
+	data, err := json.Marshal(ClaimEventData{Agent: agent, Lease: lease})
+	require.NoError(t, err)
+	return Event{Type: EventClaim, ID: id, Timestamp: ts, Actor: agent, Data: data}
+}
+
+// seedLeaseRepo has tl-stale (lease lapsed an hour ago) and tl-live (lease
+// valid for another hour), both claimed by agent-1.
+func seedLeaseRepo(t *testing.T) string {
+	t.Helper()
+	now := time.Now().UTC()
+	return seedCommandRepoWithEvents(
+		t,
+		createIssueEvent(t, "tl-stale", "Stale", StatusOpen, 1, now.Add(-3*time.Hour)),
+		createIssueEvent(t, "tl-live", "Live", StatusOpen, 1, now.Add(-3*time.Hour)),
+		claimEventAt(t, "tl-stale", "agent-1", "1h", now.Add(-2*time.Hour)),
+		claimEventAt(t, "tl-live", "agent-1", "2h", now.Add(-time.Hour)),
+	)
+}
+
+func setHeartbeatGlobals(t *testing.T, dir, agent, lease string) {
+	t.Helper()
+	setCommandGlobals(t, dir, false)
+	prevAgent, prevLease := heartbeatAgent, heartbeatLease
+	t.Cleanup(func() { heartbeatAgent, heartbeatLease = prevAgent, prevLease })
+	heartbeatAgent, heartbeatLease = agent, lease
+}
+
+func TestLeaseReplayAndExpiry(t *testing.T) {
+	graph, err := loadGraph(seedLeaseRepo(t))
+	require.NoError(t, err)
+
+	stale := graph.Tasks["tl-stale"]
+	require.NotNil(t, stale.Lease)
+	assert.Equal(t, "1h0m0s", stale.Lease.Duration)
+	assert.True(t, leaseExpired(stale, time.Now()))
+	assert.False(t, leaseExpired(graph.Tasks["tl-live"], time.Now()))
+
+	ready := collectReadyIssues(graph, computeBlockedSet(graph), time.Now())
+	require.Len(t, ready, 1)
+	assert.Equal(t, "tl-stale", ready[0].ID)
+}
+
+func TestReadyUnassignedIncludesLapsedLeases(t *testing.T) {
+	dir := seedLeaseRepo(t)
+	setReadyGlobals(t, dir, false)
+	readyUnassigned, readyIncludeInProgress = true, true
+
+	cmd := newTestCommand()
+	require.NoError(t, runReady(cmd, nil))
+	out := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, out, "tl-stale", "a lapsed lease no longer holds the task")
+	assert.NotContains(t, out, "tl-live")
+}
+
+func TestClaimWithLease(t *testing.T) {
+	dir := seedIssue(t, "tl-lease-01", "Leased", StatusOpen)
+	setClaimCommandGlobals(t, dir, false, "agent-1")
+	claimLease = "30m"
+
+	cmd := newClaimCommand(t)
+	require.NoError(t, runClaim(cmd, []string{"tl-lease-01"}))
+	assert.True(t, strings.HasPrefix(cmd.OutOrStdout().(*bytes.Buffer).String(), "Claimed tl-lease-01 by agent-1 (lease 30m0s, expires "))
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	lease := graph.Tasks["tl-lease-01"].Lease
+	require.NotNil(t, lease)
+	assert.WithinDuration(t, time.Now().Add(30*time.Minute), lease.ExpiresAt, time.Minute)
+
+	claimLease = "-5m"
+	assert.ErrorContains(t, runClaim(newClaimCommand(t), []string{"tl-lease-01"}), "must be positive")
+}
+
+func TestReclaimWithLeaseRenewsIt(t *testing.T) {
+	dir := seedIssue(t, "tl-lease-02", "Leased", StatusOpen)
+	setClaimCommandGlobals(t, dir, false, "agent-1")
+	require.NoError(t, runClaim(newClaimCommand(t), []string{"tl-lease-02"}))
+
+	claimLease = "45m"
+	cmd := newClaimCommand(t)
+	require.NoError(t, runClaim(cmd, []string{"tl-lease-02"}))
+	assert.True(t, strings.HasPrefix(cmd.OutOrStdout().(*bytes.Buffer).String(), "Already claimed tl-lease-02 by agent-1 (lease renewed until "))
+
+	events, err := readEvents(filepath.Join(dir, eventsFileName))
+	require.NoError(t, err)
+	assert.Equal(t, EventHeartbeat, events[len(events)-1].Type)
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	lease := graph.Tasks["tl-lease-02"].Lease
+	require.NotNil(t, lease, "a re-claim with --lease adds the lease the agent asked for")
+	assert.Equal(t, "45m0s", lease.Duration)
+	assert.WithinDuration(t, time.Now().Add(45*time.Minute), lease.ExpiresAt, time.Minute)
+}
+
+func TestClaimTakesOverExpiredLease(t *testing.T) {
+	dir := seedLeaseRepo(t)
+	setClaimCommandGlobals(t, dir, false, "agent-2")
+
+	err := runClaim(newClaimCommand(t), []string{"tl-live"})
+	assert.ErrorContains(t, err, "not open")
+
+	cmd := newClaimCommand(t)
+	require.NoError(t, runClaim(cmd, []string{"tl-stale"}))
+	out := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, out, "Lease held by agent-1 expired at ")
+	assert.Contains(t, out, "Claimed tl-stale by agent-2\n")
+
+	events, err := readEvents(filepath.Join(dir, eventsFileName))
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(events), 2)
+	expired := events[len(events)-2]
+	assert.Equal(t, EventLeaseExpired, expired.Type)
+	var data LeaseExpiredEventData
+	require.NoError(t, json.Unmarshal(expired.Data, &data))
+	assert.Equal(t, "agent-1", data.Agent)
+	assert.Equal(t, EventClaim, events[len(events)-1].Type)
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "agent-2", graph.Tasks["tl-stale"].Assignee)
+	assert.Nil(t, graph.Tasks["tl-stale"].Lease)
+}
+
+func TestHeartbeatRenewsLease(t *testing.T) {
+	dir := seedLeaseRepo(t)
+	before, err := loadGraph(dir)
+	require.NoError(t, err)
+	previous := before.Tasks["tl-live"].Lease.ExpiresAt
+
+	setHeartbeatGlobals(t, dir, "agent-1", "")
+	cmd := newTestCommand()
+	require.NoError(t, runHeartbeat(cmd, []string{"tl-live"}))
+	assert.True(t, strings.HasPrefix(cmd.OutOrStdout().(*bytes.Buffer).String(), "Lease on tl-live renewed until "))
+
+	after, err := loadGraph(dir)
+	require.NoError(t, err)
+	lease := after.Tasks["tl-live"].Lease
+	assert.Equal(t, "2h0m0s", lease.Duration, "keeps the original lease length")
+	assert.True(t, lease.ExpiresAt.After(previous))
+}
+
+func TestHeartbeatRejections(t *testing.T) {
+	dir := seedLeaseRepo(t)
+
+	setHeartbeatGlobals(t, dir, "agent-2", "")
+	assert.ErrorContains(t, runHeartbeat(newTestCommand(), []string{"tl-live"}), "claimed by agent-1, not agent-2")
+
+	setHeartbeatGlobals(t, dir, "agent-1", "")
+	assert.ErrorContains(t, runHeartbeat(newTestCommand(), []string{"tl-stale"}), "expired")
+	assert.ErrorIs(t, runHeartbeat(newTestCommand(), []string{"tl-nope"}), ErrNotFound)
+
+	now := time.Now().UTC()
+	unleased := seedCommandRepoWithEvents(t,
+		createIssueEvent(t, "tl-plain", "Plain", StatusOpen, 1, now),
+		claimEventAt(t, "tl-plain", "agent-1", "", now),
+	)
+	setHeartbeatGlobals(t, unleased, "agent-1", "")
+	assert.ErrorContains(t, runHeartbeat(newTestCommand(), []string{"tl-plain"}), "has no lease")
+}
+
+func TestListStaleClaims(t *testing.T) {
+	dir := seedLeaseRepo(t)
+	resetListGlobals(dir)
+	listStaleClaims = true
+	t.Cleanup(func() { resetListGlobals("") })
+
+	cmd := newTestCommand()
+	require.NoError(t, runList(cmd, nil))
+	out := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.True(t, strings.HasPrefix(out, "tl-stale [in_progress] P1 Stale (agent-1, lease expired "), out)
+	assert.NotContains(t, out, "tl-live")
+}
@@ -6,6 +6,7 @@ package tl
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -43,6 +44,15 @@ const (
 	DepDiscoveredFrom    DependencyType = "discovered-from"
 )
 
+// IsValid checks if the dependency type is a known built-in type
+func (d DependencyType) IsValid() bool {
+	switch d {
+	case DepBlocks, DepParentChild, DepConditionalBlocks, DepWaitsFor, DepRelated, DepDiscoveredFrom:
+		return true
+	}
+	return false
+}
+
 // AffectsReadyWork returns true if this dependency type blocks work
 func (d DependencyType) AffectsReadyWork() bool {
 	return d == DepBlocks || d == DepParentChild || d == DepConditionalBlocks || d == DepWaitsFor
@@ -85,6 +95,26 @@ type Issue struct {
 	Pinned             bool                       `json:"pinned,omitempty"`
 	Ephemeral          bool                       `json:"ephemeral,omitempty"`
 	Metadata           map[string]json.RawMessage `json:"metadata,omitempty"`
+	Lease              *Lease                     `json:"lease,omitempty"`
+	Comments           []*Comment                 `json:"comments,omitempty"`
+}
+
+// Lease bounds how long a claim holds without a heartbeat.
+type Lease struct {
+	Duration  string    `json:"duration"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Comment is one entry in an issue's discussion thread. IDs are numbered per
+// issue; ReplyTo names the comment being answered. The JSON shape matches
+// beads comments, which ignore reply_to.
+type Comment struct {
+	ID        int       `json:"id"`
+	IssueID   string    `json:"issue_id"`
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	ReplyTo   int       `json:"reply_to,omitempty"`
 }
 
 // Dependency represents a relationship between two issues
@@ -102,6 +132,9 @@ type Graph struct {
 	Tasks map[string]*Issue   // id → issue
 	Deps  map[string][]string // issueID → []dependsOnID
 	RDeps map[string][]string // dependsOnID → []issueID (reverse index)
+
+	blocked *blockedIndex        // maintained by applyEvent once trackBlocked is called
+	remote  map[string]remoteRef // qualified cross-repo targets → resolved state
 }
 
 // validateTransition checks if a status transition is valid
@@ -142,12 +175,12 @@ func validateTransition(from, to Status) error {
 	// Check if from status is known
 	allowed, ok := validTransitions[from]
 	if !ok {
-		return errors.New("unknown status: " + string(from))
+		return validationError("unknown status: %s", from)
 	}
 
 	// Check if to status is allowed
 	if _, valid := allowed[to]; !valid {
-		return errors.New("invalid transition: " + string(from) + " → " + string(to))
+		return fmt.Errorf("%w: %s → %s", ErrInvalidTransition, from, to)
 	}
 
 	return nil
@@ -159,4 +192,11 @@ var (
 	ErrLockBusy = errors.New("lock busy, retry")
 	ErrNotFound = errors.New("not found")
 	ErrCycle    = errors.New("dependency would create a cycle")
+
+	ErrNothingReady = errors.New("nothing ready")
+	ErrWIPLimit     = errors.New("work-in-progress limit reached")
+
+	ErrInvalidTransition = errors.New("invalid transition")
+	ErrConflict          = errors.New("conflict")
+	ErrValidation        = errors.New("invalid input")
 )
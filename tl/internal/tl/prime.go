@@ -0,0 +1,139 @@
+// ABOUTME: Session-start briefing behind `tl prime`: workflow commands plus a snapshot of the queue.
+// ABOUTME: Renders a built-in Markdown template, or .tl/prime.md when present, over a stable primeData snapshot.
+
+package tl
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/template"
+	"time"
+)
+
+const primeFileName = "prime.md"
+
+// primeData is what the briefing template sees. Lists are ordered
+// deterministically so the output only changes when the repository does.
+type primeData struct {
+	Agent          string        `json:"agent"`
+	Stats          statsOutput   `json:"stats"`
+	ReadyCount     int           `json:"ready_count"`
+	Ready          []readyIssue  `json:"ready"`
+	Claims         []readyIssue  `json:"claims"`
+	RecentlyClosed []closedIssue `json:"recently_closed"`
+}
+
+// closedIssue is a finished task as shown in the briefing.
+type closedIssue struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	ClosedAt time.Time `json:"closed_at"`
+	Reason   string    `json:"reason,omitempty"`
+}
+
+// defaultPrimeTemplate is used when .tl/prime.md does not exist. Copy it there
+// as a starting point; the fields are those of primeData.
+const defaultPrimeTemplate = `# tl workflow
+
+Work in this repository is tracked with tl. Check the queue before starting and keep it current as you go.
+
+- ` + "`tl ready`" + ` lists unblocked work, best first
+- ` + "`tl claim --next`" + ` takes the top ready task; ` + "`tl claim <id>`" + ` takes a specific one
+- ` + "`tl context <id>`" + ` prints everything known about a task
+- ` + "`tl note <id> \"...\"`" + ` records progress, dead ends included
+- ` + "`tl create \"title\"`" + ` and ` + "`tl dep add <id> <blocker>`" + ` capture work you discover
+- ` + "`tl close <id> --reason \"...\"`" + ` finishes a task; ` + "`tl release <id>`" + ` hands it back
+
+## State
+
+{{.Stats.Open}} open, {{.Stats.InProgress}} in progress, {{.Stats.Blocked}} blocked, {{.Stats.Deferred}} deferred, {{.Stats.Closed}} closed.
+
+## Claimed by {{.Agent}}
+
+{{range .Claims}}- {{.ID}} P{{.Priority}} {{.Title}}
+{{else}}- nothing claimed
+{{end}}
+## Ready ({{.ReadyCount}})
+
+{{range .Ready}}- {{.ID}} P{{.Priority}} {{.Title}}
+{{else}}- nothing ready
+{{end}}
+## Recently closed
+
+{{range .RecentlyClosed}}- {{.ID}} {{.Title}}{{if .Reason}} ({{.Reason}}){{end}}
+{{else}}- nothing closed yet
+{{end}}`
+
+// collectPrime snapshots the graph for agent. The ready head honors the
+// agent's profile, so it matches what `tl claim --next` would pick; a nil
+// profile leaves the queue unfiltered.
+func collectPrime(dir string, graph *Graph, agent string, profile *AgentProfile, limit int, now time.Time) (primeData, error) {
+	data := primeData{Agent: agent, Stats: computeStats(graph)}
+
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return data, err
+	}
+	ranked, err := rankedReadyIssues(graph, readyFilter{MaxPriority: -1, Profile: profile}, cfg, now)
+	if err != nil {
+		return data, err
+	}
+	data.ReadyCount = len(ranked)
+	data.Ready = make([]readyIssue, 0, min(limit, len(ranked)))
+	for _, issue := range ranked[:min(limit, len(ranked))] {
+		data.Ready = append(data.Ready, newReadyIssue(issue))
+	}
+
+	var claimed, closed []*Issue
+	for _, issue := range graph.Tasks {
+		switch {
+		case issue.Status == StatusInProgress && issue.Assignee == agent:
+			claimed = append(claimed, issue)
+		case issue.Status == StatusClosed && issue.ClosedAt != nil:
+			closed = append(closed, issue)
+		}
+	}
+	sortIssues(claimed)
+	data.Claims = make([]readyIssue, 0, len(claimed))
+	for _, issue := range claimed {
+		data.Claims = append(data.Claims, newReadyIssue(issue))
+	}
+
+	sort.Slice(closed, func(i, j int) bool {
+		if !closed[i].ClosedAt.Equal(*closed[j].ClosedAt) {
+			return closed[i].ClosedAt.After(*closed[j].ClosedAt)
+		}
+		return closed[i].ID < closed[j].ID
+	})
+	data.RecentlyClosed = make([]closedIssue, 0, min(limit, len(closed)))
+	for _, issue := range closed[:min(limit, len(closed))] {
+		data.RecentlyClosed = append(data.RecentlyClosed, closedIssue{ID: issue.ID, Title: issue.Title, ClosedAt: *issue.ClosedAt, Reason: issue.CloseReason})
+	}
+	return data, nil
+}
+
+// renderPrime executes .tl/prime.md when it exists, otherwise the default.
+func renderPrime(dir string, data primeData) ([]byte, error) {
+	name, text := "default", defaultPrimeTemplate
+	path := filepath.Join(dir, primeFileName)
+	raw, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		name, text = path, string(raw)
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, validationError("%s: %v", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, validationError("%s: %v", name, err)
+	}
+	return buf.Bytes(), nil
+}
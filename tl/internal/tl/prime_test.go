@@ -0,0 +1,84 @@
+// ABOUTME: Tests `tl prime`: the default briefing's sections, .tl/prime.md overrides and JSON output.
+// ABOUTME: The snapshot must list the agent's claims, the ready head and the most recently closed tasks.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seedPrimeRepo(t *testing.T) string {
+	t.Helper()
+	ts := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+	return seedCommandRepoWithEvents(t,
+		createIssueEvent(t, "tl-mine", "Mine", StatusOpen, 1, ts),
+		createIssueEvent(t, "tl-theirs", "Theirs", StatusOpen, 1, ts),
+		createIssueEvent(t, "tl-top", "Top", StatusOpen, 0, ts),
+		createIssueEvent(t, "tl-next", "Next", StatusOpen, 2, ts.Add(time.Minute)),
+		createIssueEvent(t, "tl-old", "Old", StatusOpen, 2, ts),
+		createIssueEvent(t, "tl-new", "New", StatusOpen, 2, ts),
+		claimEventAt(t, "tl-mine", "agent-1", "", ts.Add(time.Minute)),
+		claimEventAt(t, "tl-theirs", "agent-2", "", ts.Add(time.Minute)),
+		closeIssueEvent(t, "tl-old", "", ts.Add(2*time.Minute)),
+		closeIssueEvent(t, "tl-new", "superseded", ts.Add(3*time.Minute)),
+	)
+}
+
+func runPrimeOutput(t *testing.T, dir string, json bool, limit int) string {
+	t.Helper()
+	setCommandGlobals(t, dir, json)
+	prevAgent, prevLimit, prevTemplate := primeAgent, primeLimit, primeTemplate
+	t.Cleanup(func() { primeAgent, primeLimit, primeTemplate = prevAgent, prevLimit, prevTemplate })
+	primeAgent, primeLimit, primeTemplate = "agent-1", limit, false
+	cmd := newTestCommand()
+	require.NoError(t, runPrime(cmd, nil))
+	return cmd.OutOrStdout().(*bytes.Buffer).String()
+}
+
+func TestPrimeDefaultBriefing(t *testing.T) {
+	dir := seedPrimeRepo(t)
+	out := runPrimeOutput(t, dir, false, 1)
+
+	assert.Contains(t, out, "- `tl claim --next` takes the top ready task")
+	assert.Contains(t, out, "## State\n\n2 open, 2 in progress, 0 blocked, 0 deferred, 2 closed.\n")
+	assert.Contains(t, out, "## Claimed by agent-1\n\n- tl-mine P1 Mine\n\n")
+	assert.Contains(t, out, "## Ready (2)\n\n- tl-top P0 Top\n\n")
+	assert.Contains(t, out, "## Recently closed\n\n- tl-new New (superseded)\n")
+	assert.NotContains(t, out, "tl-theirs")
+	assert.Equal(t, out, runPrimeOutput(t, dir, false, 1), "the briefing is stable")
+}
+
+func TestPrimeCustomTemplateAndJSON(t *testing.T) {
+	dir := seedPrimeRepo(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, primeFileName), []byte("{{.Agent}}: {{len .Claims}} claimed, {{.ReadyCount}} ready\n"), 0644))
+	assert.Equal(t, "agent-1: 1 claimed, 2 ready\n", runPrimeOutput(t, dir, false, 5))
+
+	var data primeData
+	require.NoError(t, json.Unmarshal([]byte(runPrimeOutput(t, dir, true, 5)), &data))
+	assert.Equal(t, 2, data.Stats.Closed)
+	require.Len(t, data.RecentlyClosed, 2)
+	assert.Equal(t, "tl-new", data.RecentlyClosed[0].ID)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, primeFileName), []byte("{{.Nope}}\n"), 0644))
+	setCommandGlobals(t, dir, false)
+	err := runPrime(newTestCommand(), nil)
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.ErrorContains(t, err, primeFileName)
+}
+
+func TestPrimeTemplateFlagPrintsDefault(t *testing.T) {
+	prev := primeTemplate
+	t.Cleanup(func() { primeTemplate = prev })
+	primeTemplate = true
+	cmd := newTestCommand()
+	require.NoError(t, runPrime(cmd, nil))
+	assert.Equal(t, defaultPrimeTemplate, cmd.OutOrStdout().(*bytes.Buffer).String())
+}
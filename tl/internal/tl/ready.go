@@ -1,5 +1,5 @@
 // ABOUTME: Blocked set computation and ready queue logic for tl task graph.
-// ABOUTME: computeBlockedSet traverses dependency edges to determine which tasks are unworkable.
+// ABOUTME: computeBlockedSet propagates blocking down parent-child edges to find unworkable tasks.
 
 package tl
 
@@ -8,56 +8,125 @@ import (
 	"time"
 )
 
+// computeBlockedSet returns the IDs of issues held back by their dependencies.
+// Issues whose own edges block are found in one pass; that blocking is then
+// pushed down parent-child edges, so each edge is visited once regardless of
+// hierarchy depth. An open task parent blocks its children, but an open epic
+// does not: an epic only groups work, so its children inherit blocking only
+// while the epic itself is blocked (see dependencyBlocks).
 func computeBlockedSet(graph *Graph) map[string]bool {
-	blocked := make(map[string]bool)
 	if graph == nil {
-		return blocked
+		return make(map[string]bool)
+	}
+	if graph.blocked != nil {
+		return graph.blocked.snapshot()
+	}
+
+	blocked := make(map[string]bool)
+	var queue []string
+	for id, issue := range graph.Tasks {
+		if directlyBlocked(graph, issue) {
+			blocked[id] = true
+			queue = append(queue, id)
+		}
 	}
+	propagateBlocked(blocked, queue, childLookup(graph))
+	return blocked
+}
 
-	changed := true
-	for changed {
-		changed = false
-		for id, issue := range graph.Tasks {
-			if blocked[id] {
+// childLookup returns how to find a parent's direct children: through the
+// reverse index when the graph has one, otherwise from the issues' own
+// dependencies, so graphs built without RDeps still propagate blocking.
+func childLookup(graph *Graph) func(parentID string) []string {
+	if len(graph.RDeps) > 0 {
+		return graph.childrenOf
+	}
+	children := make(map[string][]string)
+	for id, issue := range graph.Tasks {
+		for _, parentID := range parentIDs(issue) {
+			children[parentID] = append(children[parentID], id)
+		}
+	}
+	return func(parentID string) []string { return children[parentID] }
+}
+
+// directlyBlocked reports whether one of issue's own edges blocks it, ignoring
+// blocking inherited from a blocked parent.
+func directlyBlocked(graph *Graph, issue *Issue) bool {
+	for _, dep := range issue.Dependencies {
+		if active, _ := dependencyBlocks(graph, dep, nil); active {
+			return true
+		}
+	}
+	return false
+}
+
+// inheritsBlocked reports whether issue has a parent-child edge to a blocked parent.
+func inheritsBlocked(issue *Issue, blocked map[string]bool) bool {
+	for _, dep := range issue.Dependencies {
+		if dep != nil && dep.Type == DepParentChild && blocked[dep.DependsOnID] {
+			return true
+		}
+	}
+	return false
+}
+
+// propagateBlocked marks the parent-child descendants of every queued issue as
+// blocked, breadth first; children lists the direct children of a parent.
+func propagateBlocked(blocked map[string]bool, queue []string, children func(parentID string) []string) {
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, childID := range children(current) {
+			if blocked[childID] {
 				continue
 			}
+			blocked[childID] = true
+			queue = append(queue, childID)
+		}
+	}
+}
 
-			for _, dep := range issue.Dependencies {
-				if dep == nil || !dep.Type.AffectsReadyWork() {
-					continue
-				}
-
-				depIssue, ok := graph.Tasks[dep.DependsOnID]
-				if !ok {
-					continue
-				}
-
-				if issueStatusBlocksReady(depIssue.Status) {
-					blocked[id] = true
-					changed = true
-					break
-				}
-
-				if dep.Type == DepParentChild && blocked[dep.DependsOnID] {
-					blocked[id] = true
-					changed = true
-					break
-				}
-			}
+// childrenOf lists the direct children of parentID through the reverse index.
+// Only graphs kept current by applyEvent have a complete RDeps.
+func (graph *Graph) childrenOf(parentID string) []string {
+	var children []string
+	for _, dependentID := range graph.RDeps[parentID] {
+		if issue, ok := graph.Tasks[dependentID]; ok && isChildOf(issue, parentID) {
+			children = append(children, dependentID)
 		}
 	}
+	return children
+}
 
-	return blocked
+func isChildOf(issue *Issue, parentID string) bool {
+	for _, dep := range issue.Dependencies {
+		if dep != nil && dep.Type == DepParentChild && dep.DependsOnID == parentID {
+			return true
+		}
+	}
+	return false
 }
 
 func collectReadyIssues(graph *Graph, blockedSet map[string]bool, now time.Time) []*Issue {
+	return collectReady(graph, blockedSet, now, false)
+}
+
+// collectReady returns the sorted ready queue. includeInProgress also admits
+// unblocked tasks that are already claimed (PRD §5.3 treats them as ready).
+// An epic with open children is left out: there is nothing to do on it until
+// they close.
+func collectReady(graph *Graph, blockedSet map[string]bool, now time.Time, includeInProgress bool) []*Issue {
 	if graph == nil {
 		return nil
 	}
 
 	ready := make([]*Issue, 0)
 	for _, issue := range graph.Tasks {
-		if issue.Status != StatusOpen {
+		switch {
+		case issue.Status == StatusOpen, leaseExpired(issue, now), deferLapsed(issue, now):
+		case includeInProgress && issue.Status == StatusInProgress:
+		default:
 			continue
 		}
 		if blockedSet[issue.ID] {
@@ -66,7 +135,10 @@ func collectReadyIssues(graph *Graph, blockedSet map[string]bool, now time.Time)
 		if issue.Pinned {
 			continue
 		}
-		if issue.DeferUntil != nil && !issue.DeferUntil.Before(now) {
+		if isDeferred(issue, now) {
+			continue
+		}
+		if awaitsChildren(graph, issue) {
 			continue
 		}
 		ready = append(ready, issue)
@@ -82,6 +154,49 @@ func collectReadyIssues(graph *Graph, blockedSet map[string]bool, now time.Time)
 	return ready
 }
 
+func isDeferred(issue *Issue, now time.Time) bool {
+	return issue.DeferUntil != nil && !issue.DeferUntil.Before(now)
+}
+
+// readyFilter narrows the ready queue. Zero values match everything;
+// MaxPriority < 0 means no priority limit.
+type readyFilter struct {
+	Label       string
+	Type        string
+	MaxPriority int
+	Assignee    string
+	Unassigned  bool
+	// Profile, when set, keeps only tasks the agent is qualified for.
+	Profile *AgentProfile
+}
+
+// matches reports whether issue passes the filter at now. A task whose lease
+// has lapsed counts as unassigned: its holder abandoned it and it is back in
+// the ready queue.
+func (f readyFilter) matches(issue *Issue, now time.Time) bool {
+	if f.Assignee != "" && issue.Assignee != f.Assignee {
+		return false
+	}
+	if f.Unassigned && issue.Assignee != "" && !leaseExpired(issue, now) {
+		return false
+	}
+	if f.Label != "" && !hasLabel(issue, f.Label) {
+		return false
+	}
+	if f.Type != "" && string(issue.IssueType) != f.Type {
+		return false
+	}
+	if f.MaxPriority >= 0 && issue.Priority > f.MaxPriority {
+		return false
+	}
+	if f.Profile != nil && !f.Profile.qualifies(issue) {
+		return false
+	}
+	return true
+}
+
+// issueStatusBlocksReady reports whether a dependency target in status still
+// holds its dependents back. Deferred work is postponed, not done.
 func issueStatusBlocksReady(status Status) bool {
-	return status == StatusOpen || status == StatusInProgress || status == StatusBlocked
+	return status == StatusOpen || status == StatusInProgress || status == StatusBlocked || status == StatusDeferred
 }
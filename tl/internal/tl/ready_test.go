@@ -74,6 +74,39 @@ func TestComputeBlockedSetParentChildTransitive(t *testing.T) {
 	assert.True(t, blockedSet["tl-child"])
 }
 
+func TestOpenEpicDoesNotBlockChildren(t *testing.T) {
+	now := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	child := func(id, parentID string) *Issue {
+		return &Issue{ID: id, Title: id, Status: StatusOpen, CreatedAt: now, Dependencies: []*Dependency{
+			{IssueID: id, DependsOnID: parentID, Type: DepParentChild},
+		}}
+	}
+	graph := &Graph{
+		Tasks: map[string]*Issue{
+			"tl-epic":   {ID: "tl-epic", Title: "Epic", Status: StatusOpen, IssueType: TypeEpic, CreatedAt: now},
+			"tl-task":   child("tl-task", "tl-epic"),
+			"tl-sub":    child("tl-sub", "tl-task"),
+			"tl-held":   {ID: "tl-held", Title: "Held", Status: StatusOpen, IssueType: TypeEpic, CreatedAt: now},
+			"tl-inside": child("tl-inside", "tl-held"),
+			"tl-gate":   {ID: "tl-gate", Title: "Gate", Status: StatusOpen, CreatedAt: now},
+		},
+		RDeps: map[string][]string{"tl-epic": {"tl-task"}, "tl-task": {"tl-sub"}, "tl-held": {"tl-inside"}, "tl-gate": {"tl-held"}},
+	}
+	graph.Tasks["tl-held"].Dependencies = []*Dependency{{IssueID: "tl-held", DependsOnID: "tl-gate", Type: DepBlocks}}
+
+	blockedSet := computeBlockedSet(graph)
+	assert.False(t, blockedSet["tl-task"], "an open epic groups its children without blocking them")
+	assert.True(t, blockedSet["tl-sub"], "an open task parent still blocks its children")
+	assert.True(t, blockedSet["tl-inside"], "a blocked epic blocks its children")
+
+	var ready []string
+	for _, issue := range collectReadyIssues(graph, blockedSet, now) {
+		ready = append(ready, issue.ID)
+	}
+	assert.ElementsMatch(t, []string{"tl-gate", "tl-task"}, ready, "epics with open children are not workable")
+	assert.Equal(t, "epic with open children", notReadyReason(graph, graph.Tasks["tl-epic"], blockedSet, now))
+}
+
 func TestReadyExcludesFutureDeferredIssue(t *testing.T) {
 	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
 	future := now.Add(2 * time.Hour)
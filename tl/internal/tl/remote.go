@@ -0,0 +1,149 @@
+// ABOUTME: Cross-repository dependency targets of the form "<alias>:<id>".
+// ABOUTME: Resolves aliases from config and loads the referenced repo's graph read-only to learn target status.
+
+package tl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// remoteRef is the resolved state of a qualified dependency target. Issue is
+// nil when the target could not be resolved; Err then says why and the
+// target's status is treated as unknown.
+type remoteRef struct {
+	Issue *Issue
+	Err   error
+}
+
+// splitQualifiedID splits "api:tl-a3f8" into its alias and local ID.
+func splitQualifiedID(id string) (alias, localID string, ok bool) {
+	idx := strings.Index(id, ":")
+	if idx <= 0 || idx == len(id)-1 {
+		return "", "", false
+	}
+	return id[:idx], id[idx+1:], true
+}
+
+// remoteResolver loads each remote repository at most once.
+type remoteResolver struct {
+	dir    string
+	cfg    Config
+	graphs map[string]*Graph
+	errs   map[string]error
+}
+
+func newRemoteResolver(dir string, cfg Config) *remoteResolver {
+	return &remoteResolver{dir: dir, cfg: cfg, graphs: make(map[string]*Graph), errs: make(map[string]error)}
+}
+
+// resolve looks up a qualified ID. The returned issue is a copy carrying the
+// qualified ID and no edges, so it can never be mistaken for a local task.
+func (r *remoteResolver) resolve(id string) remoteRef {
+	alias, localID, ok := splitQualifiedID(id)
+	if !ok {
+		return remoteRef{Err: fmt.Errorf("%q is not a qualified issue ID", id)}
+	}
+	graph, err := r.load(alias)
+	if err != nil {
+		return remoteRef{Err: err}
+	}
+	issue, ok := graph.Tasks[localID]
+	if !ok {
+		return remoteRef{Err: fmt.Errorf("issue %q in remote %q: %w", localID, alias, ErrNotFound)}
+	}
+	resolved := cloneIssue(issue)
+	resolved.ID = id
+	resolved.Dependencies = nil
+	return remoteRef{Issue: resolved}
+}
+
+func (r *remoteResolver) load(alias string) (*Graph, error) {
+	if graph, ok := r.graphs[alias]; ok {
+		return graph, nil
+	}
+	if err, ok := r.errs[alias]; ok {
+		return nil, err
+	}
+	graph, err := r.loadUncached(alias)
+	if err != nil {
+		err = fmt.Errorf("remote %q: %w", alias, err)
+		r.errs[alias] = err
+		return nil, err
+	}
+	r.graphs[alias] = graph
+	return graph, nil
+}
+
+// loadUncached replays the remote event log without taking its lock; the
+// remote is only ever read.
+func (r *remoteResolver) loadUncached(alias string) (*Graph, error) {
+	path, ok := r.cfg.Remotes[alias]
+	if !ok {
+		return nil, errors.New("not configured in " + configFileName)
+	}
+	remoteDir, err := resolveRemoteDir(r.dir, path)
+	if err != nil {
+		return nil, err
+	}
+	events, err := readEvents(filepath.Join(remoteDir, eventsFileName))
+	if err != nil {
+		return nil, err
+	}
+	return replayEvents(events)
+}
+
+// resolveRemoteDir finds the .tl directory for a configured remote path,
+// which may name either the repository root or its .tl directory.
+func resolveRemoteDir(dir, path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(dir), path)
+	}
+	candidate := path
+	if filepath.Base(path) != tlDirName {
+		candidate = filepath.Join(path, tlDirName)
+	}
+	info, err := os.Stat(candidate)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s exists but is not a directory", candidate)
+	}
+	return candidate, nil
+}
+
+// attachRemotes resolves every qualified dependency target in graph. Repos
+// that cannot be read are recorded with their error rather than dropped.
+func attachRemotes(graph *Graph, dir string) error {
+	var targets []string
+	for _, issue := range graph.Tasks {
+		for _, dep := range issue.Dependencies {
+			if dep == nil {
+				continue
+			}
+			if _, _, ok := splitQualifiedID(dep.DependsOnID); ok {
+				targets = append(targets, dep.DependsOnID)
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return err
+	}
+	resolver := newRemoteResolver(dir, cfg)
+	graph.remote = make(map[string]remoteRef, len(targets))
+	for _, id := range targets {
+		if _, done := graph.remote[id]; !done {
+			graph.remote[id] = resolver.resolve(id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// ABOUTME: Tests cross-repository dependency targets resolved through config remotes.
+// ABOUTME: Covers blocking on remote status, unknown (unreachable) remotes, dep tree and dep add validation.
+
+package tl
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedRemoteRepos creates sibling repos web/ and api/ under one root. web
+// configures api (readable) and ops (missing) as remotes.
+func seedRemoteRepos(t *testing.T) (webDir, apiDir string) {
+	t.Helper()
+	ts := time.Date(2026, 2, 6, 9, 0, 0, 0, time.UTC)
+	root := t.TempDir()
+
+	for _, name := range []string{"web", "api"} {
+		require.NoError(t, os.Mkdir(filepath.Join(root, name), 0755))
+		require.NoError(t, initDir(filepath.Join(root, name)))
+	}
+	webDir = filepath.Join(root, "web", tlDirName)
+	apiDir = filepath.Join(root, "api", tlDirName)
+
+	require.NoError(t, appendEventsToFile(filepath.Join(apiDir, eventsFileName), []Event{
+		createIssueEvent(t, "tl-a3f8", "Ship v2 endpoint", StatusOpen, 1, ts),
+	}))
+	require.NoError(t, appendEventsToFile(filepath.Join(webDir, eventsFileName), []Event{
+		createIssueEvent(t, "tl-ui", "Wire up UI", StatusOpen, 1, ts),
+		createIssueEvent(t, "tl-ops", "Roll out", StatusOpen, 2, ts.Add(time.Minute)),
+		depAddEvent(t, "tl-ui", "api:tl-a3f8", DepBlocks, ts.Add(2*time.Minute)),
+		depAddEvent(t, "tl-ops", "ops:tl-0001", DepBlocks, ts.Add(3*time.Minute)),
+	}))
+	require.NoError(t, os.WriteFile(filepath.Join(webDir, configFileName),
+		[]byte("remotes:\n  api: ../api\n  ops: ../ops\n"), 0644))
+	return webDir, apiDir
+}
+
+func TestSplitQualifiedID(t *testing.T) {
+	alias, id, ok := splitQualifiedID("api:tl-a3f8")
+	assert.True(t, ok)
+	assert.Equal(t, "api", alias)
+	assert.Equal(t, "tl-a3f8", id)
+
+	for _, raw := range []string{"tl-a3f8", ":tl-a3f8", "api:"} {
+		_, _, ok := splitQualifiedID(raw)
+		assert.False(t, ok, raw)
+	}
+}
+
+func TestRemoteTargetStatusDrivesBlocking(t *testing.T) {
+	webDir, apiDir := seedRemoteRepos(t)
+
+	graph, err := loadGraph(webDir)
+	require.NoError(t, err)
+	blocked := computeBlockedSet(graph)
+	assert.True(t, blocked["tl-ui"], "open remote blocker")
+	assert.True(t, blocked["tl-ops"], "unreachable remote counts as unknown, not done")
+
+	require.NoError(t, appendEventsToFile(filepath.Join(apiDir, eventsFileName), []Event{
+		closeIssueEvent(t, "tl-a3f8", "done", time.Date(2026, 2, 6, 10, 0, 0, 0, time.UTC)),
+	}))
+	graph, err = loadGraph(webDir)
+	require.NoError(t, err)
+	blocked = computeBlockedSet(graph)
+	assert.False(t, blocked["tl-ui"])
+	assert.True(t, blocked["tl-ops"])
+}
+
+func TestBlockedReportsUnknownRemote(t *testing.T) {
+	webDir, _ := seedRemoteRepos(t)
+	setCommandGlobals(t, webDir, false)
+
+	cmd := newTestCommand()
+	require.NoError(t, runBlocked(cmd, nil))
+	out := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, out, "tl-ui [open] Wire up UI (blocked by: api:tl-a3f8)\n")
+	assert.Contains(t, out, "tl-ops [open] Roll out (blocked by: ops:tl-0001)\n")
+	assert.Contains(t, out, "  blocks ops:tl-0001: ops:tl-0001 status unknown: remote \"ops\": ")
+}
+
+func TestDepTreeShowsRemoteAndUnknownTargets(t *testing.T) {
+	webDir, _ := seedRemoteRepos(t)
+	ts := time.Date(2026, 2, 6, 11, 0, 0, 0, time.UTC)
+	require.NoError(t, appendEventsToFile(filepath.Join(webDir, eventsFileName), []Event{
+		depAddEvent(t, "tl-ui", "tl-ops", DepBlocks, ts),
+	}))
+	setCommandGlobals(t, webDir, false)
+
+	cmd := newTestCommand()
+	require.NoError(t, runDepTree(cmd, []string{"tl-ui"}))
+	lines := bytes.Split(bytes.TrimSpace(cmd.OutOrStdout().(*bytes.Buffer).Bytes()), []byte("\n"))
+	require.Len(t, lines, 4)
+	assert.Equal(t, "tl-ui [open] Wire up UI", string(lines[0]))
+	assert.Equal(t, "  blocks api:tl-a3f8 [open] Ship v2 endpoint (remote)", string(lines[1]))
+	assert.Equal(t, "  blocks tl-ops [open] Roll out", string(lines[2]))
+	assert.Contains(t, string(lines[3]), "    blocks ops:tl-0001 [unknown] (remote \"ops\": ")
+}
+
+func TestDepAddQualifiedTarget(t *testing.T) {
+	webDir, _ := seedRemoteRepos(t)
+	setDepCommandGlobals(t, webDir)
+
+	err := runDepAdd(newDepCommand(t), []string{"tl-ops", "db:tl-0001"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `remote "db" is not configured`)
+
+	err = runDepAdd(newDepCommand(t), []string{"tl-ops", "api:tl-nope"})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, runDepAdd(newDepCommand(t), []string{"tl-ops", "api:tl-a3f8"}))
+
+	cmd := newDepCommand(t)
+	require.NoError(t, runDepAdd(cmd, []string{"tl-ui", "ops:tl-0002"}))
+	assert.Contains(t, cmd.OutOrStdout().(*bytes.Buffer).String(), "warning: ops:tl-0002 status unknown")
+}
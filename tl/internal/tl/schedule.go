@@ -0,0 +1,226 @@
+// ABOUTME: Recurring task schedules loaded from .tl/schedules.yaml and instantiated by `tl tick`.
+// ABOUTME: Each due occurrence becomes a normal task linked discovered-from the schedule's pinned anchor issue.
+
+package tl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const schedulesFileName = "schedules.yaml"
+
+// Metadata keys recorded on schedule instances; together they make tick
+// idempotent.
+const (
+	scheduleMetadataKey   = "schedule"
+	occurrenceMetadataKey = "occurrence"
+)
+
+// scheduleLabel marks the pinned anchor issue that stands for a schedule.
+const scheduleLabel = "schedule"
+
+var scheduleNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Schedule describes a task that recurs on a cron rule.
+type Schedule struct {
+	Name        string   `yaml:"-" json:"name"`
+	Cron        string   `yaml:"cron" json:"cron"`
+	Title       string   `yaml:"title" json:"title"`
+	Description string   `yaml:"description" json:"description,omitempty"`
+	Priority    *int     `yaml:"priority" json:"priority,omitempty"`
+	Type        string   `yaml:"type" json:"type,omitempty"`
+	Labels      []string `yaml:"labels" json:"labels,omitempty"`
+	// Start suppresses occurrences before it.
+	Start *time.Time `yaml:"start" json:"start,omitempty"`
+
+	expr *cronExpr
+}
+
+type schedulesFile struct {
+	Schedules map[string]*Schedule `yaml:"schedules"`
+}
+
+// loadSchedules reads and validates schedules, ordered by name. A missing
+// file yields none.
+func loadSchedules(dir string) ([]*Schedule, error) {
+	path := filepath.Join(dir, schedulesFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var file schedulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	schedules := make([]*Schedule, 0, len(file.Schedules))
+	for name, sched := range file.Schedules {
+		if sched == nil {
+			return nil, validationError("%s: schedule %q is empty", path, name)
+		}
+		if !scheduleNamePattern.MatchString(name) {
+			return nil, validationError("%s: schedule name %q must be lowercase letters, digits and dashes", path, name)
+		}
+		if sched.Title == "" {
+			return nil, validationError("%s: schedule %q has no title", path, name)
+		}
+		expr, err := parseCron(sched.Cron)
+		if err != nil {
+			return nil, validationError("%s: schedule %q: %w", path, name, err)
+		}
+		sched.Name = name
+		sched.expr = expr
+		schedules = append(schedules, sched)
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].Name < schedules[j].Name })
+	return schedules, nil
+}
+
+// anchorID is the ID of the pinned issue standing for a schedule.
+func (s *Schedule) anchorID() string {
+	return "tl-sched-" + s.Name
+}
+
+// dueOccurrence returns the occurrence tick should instantiate at now: the
+// latest one at or before now. Missed earlier occurrences collapse into it.
+func (s *Schedule) dueOccurrence(now time.Time) (time.Time, bool) {
+	occurrence, ok := s.expr.prev(now)
+	if !ok || (s.Start != nil && occurrence.Before(*s.Start)) {
+		return time.Time{}, false
+	}
+	return occurrence, true
+}
+
+// tickResult is one instance created by tick.
+type tickResult struct {
+	Schedule   string    `json:"schedule"`
+	Occurrence time.Time `json:"occurrence"`
+	Issue      Issue     `json:"issue"`
+}
+
+// latestOccurrences maps each schedule name to its newest instantiated
+// occurrence, read from instance metadata.
+func latestOccurrences(graph *Graph) map[string]time.Time {
+	latest := make(map[string]time.Time)
+	for _, issue := range graph.Tasks {
+		var name string
+		var occurrence time.Time
+		if json.Unmarshal(issue.Metadata[scheduleMetadataKey], &name) != nil || name == "" {
+			continue
+		}
+		if json.Unmarshal(issue.Metadata[occurrenceMetadataKey], &occurrence) != nil {
+			continue
+		}
+		if occurrence.After(latest[name]) {
+			latest[name] = occurrence
+		}
+	}
+	return latest
+}
+
+// tick creates every due schedule instance in one mutate, so concurrent or
+// repeated runs never duplicate an occurrence.
+func tick(dir string, now time.Time) ([]tickResult, error) {
+	schedules, err := loadSchedules(dir)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]tickResult, 0)
+	if len(schedules) == 0 {
+		return results, nil
+	}
+
+	err = mutate(dir, func(g *Graph) ([]Event, error) {
+		latest := latestOccurrences(g)
+		var events []Event
+		apply := func(eventType, id string, data any) error {
+			evt, err := newEvent(eventType, id, data)
+			if err != nil {
+				return err
+			}
+			if err := g.applyEvent(evt); err != nil {
+				return err
+			}
+			events = append(events, evt)
+			return nil
+		}
+
+		for _, sched := range schedules {
+			occurrence, ok := sched.dueOccurrence(now)
+			if !ok || !occurrence.After(latest[sched.Name]) {
+				continue
+			}
+
+			anchor := sched.anchorID()
+			if _, exists := g.Tasks[anchor]; !exists {
+				err := apply(EventCreate, anchor, CreateEventData{
+					Title:       fmt.Sprintf("Schedule %s (%s)", sched.Name, sched.Cron),
+					Description: sched.Title,
+					Status:      string(StatusPinned),
+					IssueType:   string(TypeChore),
+					Labels:      []string{scheduleLabel},
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			data, err := sched.instanceData(occurrence)
+			if err != nil {
+				return nil, err
+			}
+			id := generateID()
+			for g.Tasks[id] != nil {
+				id = generateID()
+			}
+			if err := apply(EventCreate, id, data); err != nil {
+				return nil, err
+			}
+			if err := apply(EventDepAdd, id, DepAddEventData{DependsOnID: anchor, DepType: string(DepDiscoveredFrom)}); err != nil {
+				return nil, err
+			}
+			results = append(results, tickResult{Schedule: sched.Name, Occurrence: occurrence, Issue: *g.Tasks[id]})
+		}
+		return events, nil
+	})
+	return results, err
+}
+
+func (s *Schedule) instanceData(occurrence time.Time) (CreateEventData, error) {
+	name, err := json.Marshal(s.Name)
+	if err != nil {
+		return CreateEventData{}, err
+	}
+	at, err := json.Marshal(occurrence.UTC())
+	if err != nil {
+		return CreateEventData{}, err
+	}
+	data := CreateEventData{
+		Title:       s.Title,
+		Description: s.Description,
+		Status:      string(StatusOpen),
+		Priority:    2,
+		IssueType:   s.Type,
+		Labels:      s.Labels,
+		Metadata:    map[string]json.RawMessage{scheduleMetadataKey: name, occurrenceMetadataKey: at},
+	}
+	if s.Priority != nil {
+		data.Priority = *s.Priority
+	}
+	if data.IssueType == "" {
+		data.IssueType = string(TypeTask)
+	}
+	return data, nil
+}
@@ -0,0 +1,140 @@
+// ABOUTME: Tests recurring schedules and tl tick.
+// ABOUTME: Covers schedules.yaml validation, due-occurrence selection, discovered-from anchors and idempotent re-runs.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchedulesYAML = `schedules:
+  deps-bump:
+    cron: "0 9 * * 1"
+    title: Bump dependencies
+    priority: 1
+    type: chore
+    labels: [deps]
+  rotate-secrets:
+    cron: "@monthly"
+    title: Rotate secrets
+  future:
+    cron: "@daily"
+    title: Not yet
+    start: 2030-01-01T00:00:00Z
+`
+
+func seedScheduleRepo(t *testing.T) string {
+	t.Helper()
+	dir := seedCommandRepoWithEvents(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, schedulesFileName), []byte(testSchedulesYAML), 0644))
+	return dir
+}
+
+func scheduleInstances(t *testing.T, dir, name string) []*Issue {
+	t.Helper()
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	var out []*Issue
+	for _, issue := range graph.Tasks {
+		var sched string
+		if json.Unmarshal(issue.Metadata[scheduleMetadataKey], &sched) == nil && sched == name {
+			out = append(out, issue)
+		}
+	}
+	return out
+}
+
+func TestLoadSchedulesValidates(t *testing.T) {
+	dir := seedScheduleRepo(t)
+	schedules, err := loadSchedules(dir)
+	require.NoError(t, err)
+	require.Len(t, schedules, 3)
+	assert.Equal(t, "deps-bump", schedules[0].Name)
+	assert.Equal(t, "tl-sched-deps-bump", schedules[0].anchorID())
+
+	for body, want := range map[string]string{
+		"schedules:\n  x:\n    cron: \"@daily\"\n":                      "has no title",
+		"schedules:\n  x:\n    cron: \"@often\"\n    title: T\n":        "invalid cron",
+		"schedules:\n  Bad_Name:\n    cron: \"@daily\"\n    title: T\n": "lowercase",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, schedulesFileName), []byte(body), 0644))
+		_, err := loadSchedules(dir)
+		assert.ErrorContains(t, err, want)
+	}
+
+	none, err := loadSchedules(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestTickCreatesDueInstancesOnce(t *testing.T) {
+	dir := seedScheduleRepo(t)
+	// Wednesday 2026-03-04.
+	now := time.Date(2026, 3, 4, 15, 0, 0, 0, time.Local)
+
+	results, err := tick(dir, now)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "deps-bump", results[0].Schedule)
+	assert.Equal(t, time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local), results[0].Occurrence)
+	assert.Equal(t, "rotate-secrets", results[1].Schedule)
+
+	bump := results[0].Issue
+	assert.Equal(t, "Bump dependencies", bump.Title)
+	assert.Equal(t, 1, bump.Priority)
+	assert.Equal(t, TypeChore, bump.IssueType)
+	assert.Equal(t, []string{"deps"}, bump.Labels)
+	require.Len(t, bump.Dependencies, 1)
+	assert.Equal(t, "tl-sched-deps-bump", bump.Dependencies[0].DependsOnID)
+	assert.Equal(t, DepDiscoveredFrom, bump.Dependencies[0].Type)
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	anchor := graph.Tasks["tl-sched-deps-bump"]
+	require.NotNil(t, anchor)
+	assert.Equal(t, StatusPinned, anchor.Status)
+	ready := collectReadyIssues(graph, computeBlockedSet(graph), now)
+	assert.Len(t, ready, 2, "anchors never show up as work")
+
+	again, err := tick(dir, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, again, "a second tick in the same period creates nothing")
+	assert.Len(t, scheduleInstances(t, dir, "deps-bump"), 1)
+
+	// Three weeks later the missed Mondays collapse into one instance.
+	later, err := tick(dir, now.AddDate(0, 0, 21))
+	require.NoError(t, err)
+	require.Len(t, later, 1)
+	assert.Equal(t, time.Date(2026, 3, 23, 9, 0, 0, 0, time.Local), later[0].Occurrence)
+	assert.Len(t, scheduleInstances(t, dir, "deps-bump"), 2)
+	assert.Len(t, scheduleInstances(t, dir, "rotate-secrets"), 1)
+	assert.Empty(t, scheduleInstances(t, dir, "future"))
+}
+
+func TestTickCommandOutput(t *testing.T) {
+	dir := seedScheduleRepo(t)
+	setCommandGlobals(t, dir, false)
+
+	cmd := newTestCommand()
+	require.NoError(t, runTick(cmd, nil))
+	out := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, out, ": Bump dependencies (deps-bump, due ")
+	assert.Contains(t, out, ": Rotate secrets (rotate-secrets, due ")
+
+	cmd = newTestCommand()
+	require.NoError(t, runTick(cmd, nil))
+	assert.Equal(t, "Nothing due\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	jsonOutput = true
+	cmd = newTestCommand()
+	require.NoError(t, runTick(cmd, nil))
+	assert.Equal(t, "[]\n", cmd.OutOrStdout().(*bytes.Buffer).String())
+}
@@ -0,0 +1,104 @@
+// ABOUTME: Pluggable ready-queue ordering strategies and their per-task scores.
+// ABOUTME: strict, aging, critical-path-first and downstream-unblock-count-first; higher scores sort first.
+
+package tl
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Ready-queue strategies selectable via config (ready.strategy) or --strategy.
+const (
+	StrategyStrict       = "strict"
+	StrategyAging        = "aging"
+	StrategyCriticalPath = "critical-path-first"
+	StrategyUnblockCount = "downstream-unblock-count-first"
+)
+
+// defaultAgingDays is how long a task waits to gain one priority level under the aging strategy.
+const defaultAgingDays = 7
+
+func knownStrategies() []string {
+	return []string{StrategyStrict, StrategyAging, StrategyCriticalPath, StrategyUnblockCount}
+}
+
+// scoredIssue pairs a ready issue with the score its strategy assigned.
+type scoredIssue struct {
+	*Issue
+	Score float64
+}
+
+// resolveStrategy picks the flag value, else the configured strategy, else strict.
+func resolveStrategy(flag string, cfg Config) (string, error) {
+	strategy := flag
+	if strategy == "" {
+		strategy = cfg.Ready.Strategy
+	}
+	if strategy == "" {
+		return StrategyStrict, nil
+	}
+	for _, known := range knownStrategies() {
+		if strategy == known {
+			return strategy, nil
+		}
+	}
+	return "", validationError("unknown ready strategy %q (want one of: %s)", strategy, strings.Join(knownStrategies(), ", "))
+}
+
+// scoreReady orders ready issues by strategy. Ties fall back to the strict
+// order: priority, then creation time.
+func scoreReady(graph *Graph, ready []*Issue, strategy string, cfg Config, now time.Time) ([]scoredIssue, error) {
+	scored := make([]scoredIssue, len(ready))
+	for i, issue := range ready {
+		scored[i] = scoredIssue{Issue: issue}
+	}
+
+	switch strategy {
+	case StrategyStrict, "":
+		for i := range scored {
+			scored[i].Score = -float64(scored[i].Priority)
+		}
+	case StrategyAging:
+		days := cfg.Ready.AgingDays
+		if days <= 0 {
+			days = defaultAgingDays
+		}
+		for i := range scored {
+			waited := now.Sub(scored[i].CreatedAt).Hours() / 24
+			effective := math.Max(0, float64(scored[i].Priority)-waited/days)
+			scored[i].Score = -math.Round(effective*100) / 100
+		}
+	case StrategyCriticalPath:
+		// Tasks in or behind a dependency cycle have no schedule and score 0.
+		result := computeCriticalPathSkippingCycles(graph, ready)
+		through := make(map[string]int, len(result.Tasks))
+		for _, task := range result.Tasks {
+			// Length of the longest chain passing through the task.
+			through[task.ID] = result.Length - task.Slack
+		}
+		for i := range scored {
+			scored[i].Score = float64(through[scored[i].ID])
+		}
+	case StrategyUnblockCount:
+		for i := range scored {
+			scored[i].Score = float64(len(downstreamIDs(graph, scored[i].ID)))
+		}
+	default:
+		return nil, fmt.Errorf("unknown ready strategy %q", strategy)
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score > scored[j].Score
+		}
+		if scored[i].Priority != scored[j].Priority {
+			return scored[i].Priority < scored[j].Priority
+		}
+		return scored[i].CreatedAt.Before(scored[j].CreatedAt)
+	})
+	return scored, nil
+}
@@ -0,0 +1,170 @@
+// ABOUTME: Tests ready-queue scoring strategies and their selection via config and --strategy.
+// ABOUTME: Each strategy ranks the same fixture differently; scores appear in ready --json and drive claim --next.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var scoringNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// scoringEvents builds a queue where every strategy picks a different head:
+// tl-new is most urgent, tl-old has waited longest, tl-chain heads the
+// longest chain and tl-fan unblocks the most tasks.
+func scoringEvents(t *testing.T) []Event {
+	t.Helper()
+	recent := scoringNow.Add(-time.Hour)
+	events := []Event{
+		createIssueEvent(t, "tl-old", "Old chore", StatusOpen, 2, scoringNow.Add(-30*24*time.Hour)),
+		createIssueEvent(t, "tl-new", "Urgent fix", StatusOpen, 1, recent),
+		createIssueEvent(t, "tl-chain", "Chain head", StatusOpen, 3, recent),
+		createIssueEvent(t, "tl-chain2", "Chain middle", StatusOpen, 3, recent),
+		createIssueEvent(t, "tl-chain3", "Chain tail", StatusOpen, 3, recent),
+		createIssueEvent(t, "tl-fan", "Fan out", StatusOpen, 3, recent.Add(time.Minute)),
+		depAddEvent(t, "tl-chain2", "tl-chain", DepBlocks, recent),
+		depAddEvent(t, "tl-chain3", "tl-chain2", DepBlocks, recent),
+	}
+	for _, leaf := range []string{"tl-leaf1", "tl-leaf2", "tl-leaf3"} {
+		events = append(events,
+			createIssueEvent(t, leaf, "Leaf", StatusOpen, 3, recent),
+			depAddEvent(t, leaf, "tl-fan", DepBlocks, recent))
+	}
+	return events
+}
+
+func scoredIDs(scored []scoredIssue) []string {
+	ids := make([]string, len(scored))
+	for i, s := range scored {
+		ids[i] = s.ID
+	}
+	return ids
+}
+
+func TestScoreReadyStrategies(t *testing.T) {
+	graph, err := replayEvents(scoringEvents(t))
+	require.NoError(t, err)
+	ready := collectReadyIssues(graph, computeBlockedSet(graph), scoringNow)
+
+	rank := func(strategy string) []scoredIssue {
+		scored, err := scoreReady(graph, ready, strategy, Config{}, scoringNow)
+		require.NoError(t, err)
+		return scored
+	}
+
+	strict := rank(StrategyStrict)
+	assert.Equal(t, []string{"tl-new", "tl-old", "tl-chain", "tl-fan"}, scoredIDs(strict))
+	assert.Equal(t, -1.0, strict[0].Score)
+
+	aging := rank(StrategyAging)
+	assert.Equal(t, "tl-old", aging[0].ID, "30 days at one level per week outranks P1")
+	assert.Equal(t, 0.0, aging[0].Score)
+
+	critical := rank(StrategyCriticalPath)
+	assert.Equal(t, "tl-chain", critical[0].ID)
+	assert.Greater(t, critical[0].Score, critical[1].Score)
+
+	unblock := rank(StrategyUnblockCount)
+	assert.Equal(t, "tl-fan", unblock[0].ID)
+	assert.Equal(t, 3.0, unblock[0].Score)
+	assert.Equal(t, 2.0, unblock[1].Score)
+
+	slow, err := scoreReady(graph, ready, StrategyAging, Config{Ready: ReadyConfig{AgingDays: 60}}, scoringNow)
+	require.NoError(t, err)
+	assert.Equal(t, "tl-new", slow[0].ID, "a slower aging rate keeps the P1 task on top")
+}
+
+func TestResolveStrategy(t *testing.T) {
+	strategy, err := resolveStrategy("", Config{})
+	require.NoError(t, err)
+	assert.Equal(t, StrategyStrict, strategy)
+
+	strategy, err = resolveStrategy("", Config{Ready: ReadyConfig{Strategy: StrategyAging}})
+	require.NoError(t, err)
+	assert.Equal(t, StrategyAging, strategy)
+
+	strategy, err = resolveStrategy(StrategyUnblockCount, Config{Ready: ReadyConfig{Strategy: StrategyAging}})
+	require.NoError(t, err)
+	assert.Equal(t, StrategyUnblockCount, strategy, "flag overrides config")
+
+	_, err = resolveStrategy("fifo", Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown ready strategy "fifo"`)
+}
+
+func TestReadyStrategyFlagAndConfig(t *testing.T) {
+	dir := seedCommandRepoWithEvents(t, scoringEvents(t)...)
+	setReadyGlobals(t, dir, true)
+
+	run := func() []readyIssue {
+		cmd := newTestCommand()
+		require.NoError(t, runReady(cmd, nil))
+		var rows []readyIssue
+		require.NoError(t, json.Unmarshal(bytes.TrimSpace(cmd.OutOrStdout().(*bytes.Buffer).Bytes()), &rows))
+		return rows
+	}
+
+	rows := run()
+	require.NotEmpty(t, rows)
+	assert.Equal(t, "tl-new", rows[0].ID)
+	require.NotNil(t, rows[0].Score)
+	assert.Equal(t, -1.0, *rows[0].Score)
+
+	readyStrategy = StrategyUnblockCount
+	rows = run()
+	assert.Equal(t, "tl-fan", rows[0].ID)
+	assert.Equal(t, 3.0, *rows[0].Score)
+
+	readyStrategy = ""
+	require.NoError(t, os.WriteFile(filepath.Join(dir, configFileName), []byte("ready:\n  strategy: critical-path-first\n"), 0644))
+	assert.Equal(t, "tl-chain", run()[0].ID)
+
+	readyStrategy = "fifo"
+	assert.Error(t, runReady(newTestCommand(), nil))
+}
+
+func TestClaimNextFollowsConfiguredStrategy(t *testing.T) {
+	dir := seedCommandRepoWithEvents(t, scoringEvents(t)...)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, configFileName), []byte("ready:\n  strategy: downstream-unblock-count-first\n"), 0644))
+
+	result, err := claimTask(dir, claimRequest{Agent: "alice", Filter: readyFilter{MaxPriority: -1}})
+	require.NoError(t, err)
+	assert.Equal(t, "tl-fan", result.Issue.ID)
+}
+
+func TestCriticalPathStrategyToleratesCycles(t *testing.T) {
+	events := append(scoringEvents(t),
+		createIssueEvent(t, "tl-cyc-a", "Cycle A", StatusOpen, 0, scoringNow.Add(-time.Hour)),
+		createIssueEvent(t, "tl-cyc-b", "Cycle B", StatusOpen, 0, scoringNow.Add(-time.Hour)),
+		depAddEvent(t, "tl-cyc-a", "tl-cyc-b", DepParentChild, scoringNow.Add(-time.Hour)),
+		depAddEvent(t, "tl-cyc-b", "tl-cyc-a", DepParentChild, scoringNow.Add(-time.Hour)),
+	)
+	graph, err := replayEvents(events)
+	require.NoError(t, err)
+	ready := collectReadyIssues(graph, computeBlockedSet(graph), scoringNow)
+
+	scored, err := scoreReady(graph, ready, StrategyCriticalPath, Config{}, scoringNow)
+	require.NoError(t, err, "a cycle elsewhere in the graph must not fail the ready queue")
+	acyclic, err := replayEvents(scoringEvents(t))
+	require.NoError(t, err)
+	want, err := scoreReady(acyclic, collectReadyIssues(acyclic, computeBlockedSet(acyclic), scoringNow), StrategyCriticalPath, Config{}, scoringNow)
+	require.NoError(t, err)
+	assert.Equal(t, want, scored, "the rest of the queue ranks as if the cycle were absent")
+
+	_, err = computeCriticalPath(graph, "", ready)
+	assert.ErrorIs(t, err, ErrCycle, "tl critical-path still reports the cycle")
+
+	dir := seedCommandRepoWithEvents(t, events...)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, configFileName), []byte("ready:\n  strategy: critical-path-first\n"), 0644))
+	result, err := claimTask(dir, claimRequest{Agent: "alice", Filter: readyFilter{MaxPriority: -1}})
+	require.NoError(t, err)
+	assert.Equal(t, "tl-chain", result.Issue.ID)
+}
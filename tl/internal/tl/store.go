@@ -14,6 +14,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
 const (
@@ -78,7 +80,7 @@ func tlDir(opts GlobalOptions) (string, error) {
 func initDir(path string) error {
 	dirPath := filepath.Join(path, tlDirName)
 	if _, err := os.Stat(dirPath); err == nil {
-		return fmt.Errorf("already initialized at %s", path)
+		return conflictError("already initialized at %s", path)
 	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
 		return err
 	}
@@ -173,196 +175,430 @@ func readEvents(path string) ([]Event, error) {
 	return events, nil
 }
 
-func replayEvents(events []Event) (*Graph, error) {
-	graph := &Graph{
+func newGraph() *Graph {
+	return &Graph{
 		Tasks: make(map[string]*Issue),
 		Deps:  make(map[string][]string),
 		RDeps: make(map[string][]string),
 	}
+}
+
+// cloneGraph deep-copies the graph so callers can simulate events without
+// touching the original.
+func cloneGraph(graph *Graph) *Graph {
+	out := newGraph()
+	for id, issue := range graph.Tasks {
+		out.Tasks[id] = cloneIssue(issue)
+	}
+	for id, deps := range graph.Deps {
+		out.Deps[id] = append([]string(nil), deps...)
+	}
+	for id, rdeps := range graph.RDeps {
+		out.RDeps[id] = append([]string(nil), rdeps...)
+	}
+	if graph.remote != nil {
+		out.remote = make(map[string]remoteRef, len(graph.remote))
+		for id, ref := range graph.remote {
+			out.remote[id] = ref
+		}
+	}
+	if graph.blocked != nil {
+		out.blocked = graph.blocked.clone()
+	}
+	return out
+}
 
+func replayEvents(events []Event) (*Graph, error) {
+	graph := newGraph()
 	for _, event := range events {
-		switch event.Type {
-		case EventCreate:
-			var data CreateEventData
-			if err := json.Unmarshal(event.Data, &data); err != nil {
-				return nil, err
-			}
-			status := Status(data.Status)
-			if status == "" {
-				status = StatusOpen
-			}
-			graph.Tasks[event.ID] = &Issue{
-				ID:          event.ID,
-				Title:       data.Title,
-				Description: data.Description,
-				Status:      status,
-				Priority:    data.Priority,
-				IssueType:   IssueType(data.IssueType),
-				Labels:      data.Labels,
-				CreatedAt:   event.Timestamp,
-				UpdatedAt:   event.Timestamp,
-				Metadata:    data.Metadata,
-			}
+		if err := graph.applyEvent(event); err != nil {
+			return nil, err
+		}
+	}
+	return graph, nil
+}
 
-		case EventUpdate:
-			var data UpdateEventData
-			if err := json.Unmarshal(event.Data, &data); err != nil {
-				return nil, err
-			}
-			issue, ok := graph.Tasks[event.ID]
-			if !ok {
-				continue
-			}
+// applyEvent folds a single event into the graph. Replay and what-if
+// simulations both go through here so they share one definition of each event.
+// Graphs that track their blocked set update it for the issues the event touches.
+func (graph *Graph) applyEvent(event Event) error {
+	if graph.blocked == nil {
+		return graph.foldEvent(event)
+	}
+	affected := graph.blocked.affectedBy(graph, event.ID)
+	if err := graph.foldEvent(event); err != nil {
+		return err
+	}
+	graph.blocked.update(graph, append(affected, graph.blocked.affectedBy(graph, event.ID)...))
+	return nil
+}
 
-			for field, value := range data.Fields {
-				switch field {
-				case "status":
-					var status Status
-					if err := json.Unmarshal(value, &status); err != nil {
-						return nil, err
-					}
-					issue.Status = status
-				case "title":
-					var title string
-					if err := json.Unmarshal(value, &title); err != nil {
-						return nil, err
-					}
-					issue.Title = title
-				case "description":
-					var description string
-					if err := json.Unmarshal(value, &description); err != nil {
-						return nil, err
-					}
-					issue.Description = description
-				case "priority":
-					var priority int
-					if err := json.Unmarshal(value, &priority); err != nil {
-						return nil, err
-					}
-					issue.Priority = priority
-				case "assignee":
-					var assignee string
-					if err := json.Unmarshal(value, &assignee); err != nil {
-						return nil, err
-					}
-					issue.Assignee = assignee
-				case "close_reason":
-					var closeReason string
-					if err := json.Unmarshal(value, &closeReason); err != nil {
-						return nil, err
-					}
-					issue.CloseReason = closeReason
-				default:
-					if issue.Metadata == nil {
-						issue.Metadata = make(map[string]json.RawMessage)
-					}
-					issue.Metadata[field] = value
+func (graph *Graph) foldEvent(event Event) error {
+	switch event.Type {
+	case EventCreate:
+		var data CreateEventData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		status := Status(data.Status)
+		if status == "" {
+			status = StatusOpen
+		}
+		graph.Tasks[event.ID] = &Issue{
+			ID:          event.ID,
+			Title:       data.Title,
+			Description: data.Description,
+			Status:      status,
+			Priority:    data.Priority,
+			IssueType:   IssueType(data.IssueType),
+			Labels:      data.Labels,
+			CreatedAt:   event.Timestamp,
+			UpdatedAt:   event.Timestamp,
+			Metadata:    data.Metadata,
+			DeferUntil:  data.DeferUntil,
+			Notes:       data.Notes,
+			SpecID:      data.SpecID,
+		}
+
+	case EventUpdate:
+		var data UpdateEventData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		issue, ok := graph.Tasks[event.ID]
+		if !ok {
+			return nil
+		}
+
+		for field, value := range data.Fields {
+			switch field {
+			case "status":
+				var status Status
+				if err := json.Unmarshal(value, &status); err != nil {
+					return err
+				}
+				issue.Status = status
+				if status != StatusInProgress {
+					issue.Lease = nil
+				}
+				if status != StatusDeferred {
+					issue.DeferUntil = nil
+				}
+			case "title":
+				var title string
+				if err := json.Unmarshal(value, &title); err != nil {
+					return err
+				}
+				issue.Title = title
+			case "description":
+				var description string
+				if err := json.Unmarshal(value, &description); err != nil {
+					return err
 				}
+				issue.Description = description
+			case "notes":
+				var notes string
+				if err := json.Unmarshal(value, &notes); err != nil {
+					return err
+				}
+				issue.Notes = notes
+			case "spec_id":
+				var specID string
+				if err := json.Unmarshal(value, &specID); err != nil {
+					return err
+				}
+				issue.SpecID = specID
+			case "priority":
+				var priority int
+				if err := json.Unmarshal(value, &priority); err != nil {
+					return err
+				}
+				issue.Priority = priority
+			case "assignee":
+				var assignee string
+				if err := json.Unmarshal(value, &assignee); err != nil {
+					return err
+				}
+				issue.Assignee = assignee
+			case "issue_type":
+				var issueType IssueType
+				if err := json.Unmarshal(value, &issueType); err != nil {
+					return err
+				}
+				issue.IssueType = issueType
+			case "labels":
+				var labels []string
+				if err := json.Unmarshal(value, &labels); err != nil {
+					return err
+				}
+				issue.Labels = labels
+			case "close_reason":
+				var closeReason string
+				if err := json.Unmarshal(value, &closeReason); err != nil {
+					return err
+				}
+				issue.CloseReason = closeReason
+			default:
+				if issue.Metadata == nil {
+					issue.Metadata = make(map[string]json.RawMessage)
+				}
+				issue.Metadata[field] = value
 			}
-			issue.UpdatedAt = event.Timestamp
+		}
+		issue.UpdatedAt = event.Timestamp
 
-		case EventClose:
-			var data CloseEventData
-			if err := json.Unmarshal(event.Data, &data); err != nil {
-				return nil, err
-			}
-			issue, ok := graph.Tasks[event.ID]
-			if !ok {
-				continue
-			}
-			issue.Status = StatusClosed
-			issue.CloseReason = data.Reason
-			closedAt := event.Timestamp
-			issue.ClosedAt = &closedAt
-			issue.UpdatedAt = event.Timestamp
-			clearBlockingEdges(graph, event.ID)
-
-		case EventReopen:
-			issue, ok := graph.Tasks[event.ID]
-			if !ok {
-				continue
-			}
-			issue.Status = StatusOpen
-			issue.ClosedAt = nil
-			issue.CloseReason = ""
-			issue.Assignee = ""
-			issue.UpdatedAt = event.Timestamp
-
-		case EventClaim:
-			var data ClaimEventData
-			if err := json.Unmarshal(event.Data, &data); err != nil {
-				return nil, err
-			}
-			issue, ok := graph.Tasks[event.ID]
-			if !ok {
-				continue
-			}
-			issue.Status = StatusInProgress
-			issue.Assignee = data.Agent
-			issue.UpdatedAt = event.Timestamp
+	case EventClose:
+		var data CloseEventData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		issue, ok := graph.Tasks[event.ID]
+		if !ok {
+			return nil
+		}
+		issue.Status = StatusClosed
+		issue.CloseReason = data.Reason
+		issue.DeferUntil = nil
+		closedAt := event.Timestamp
+		issue.ClosedAt = &closedAt
+		issue.Lease = nil
+		issue.UpdatedAt = event.Timestamp
+		clearBlockingEdges(graph, event.ID)
+
+	case EventReopen:
+		issue, ok := graph.Tasks[event.ID]
+		if !ok {
+			return nil
+		}
+		issue.Status = StatusOpen
+		issue.ClosedAt = nil
+		issue.CloseReason = ""
+		issue.Assignee = ""
+		issue.Lease = nil
+		issue.DeferUntil = nil
+		issue.UpdatedAt = event.Timestamp
+
+	case EventClaim:
+		var data ClaimEventData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		issue, ok := graph.Tasks[event.ID]
+		if !ok {
+			return nil
+		}
+		issue.Status = StatusInProgress
+		issue.Assignee = data.Agent
+		issue.Lease = leaseFrom(data.Lease, event.Timestamp)
+		issue.DeferUntil = nil
+		issue.UpdatedAt = event.Timestamp
+
+	case EventHeartbeat:
+		var data HeartbeatEventData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		issue, ok := graph.Tasks[event.ID]
+		if !ok {
+			return nil
+		}
+		if lease := leaseFrom(data.Lease, event.Timestamp); lease != nil {
+			issue.Lease = lease
+		}
+		issue.UpdatedAt = event.Timestamp
 
-		case EventDepAdd:
-			var data DepAddEventData
-			if err := json.Unmarshal(event.Data, &data); err != nil {
-				return nil, err
-			}
-			graph.Deps[event.ID] = append(graph.Deps[event.ID], data.DependsOnID)
-			graph.RDeps[data.DependsOnID] = append(graph.RDeps[data.DependsOnID], event.ID)
-			issue, ok := graph.Tasks[event.ID]
-			if !ok {
-				continue
-			}
-			issue.Dependencies = append(issue.Dependencies, &Dependency{
-				IssueID:     event.ID,
-				DependsOnID: data.DependsOnID,
-				Type:        DependencyType(data.DepType),
-				CreatedAt:   event.Timestamp,
-				CreatedBy:   event.Actor,
-			})
-
-		case EventDepRemove:
-			var data DepRemoveEventData
-			if err := json.Unmarshal(event.Data, &data); err != nil {
-				return nil, err
-			}
-			graph.Deps[event.ID] = removeString(graph.Deps[event.ID], data.DependsOnID)
-			if len(graph.Deps[event.ID]) == 0 {
-				delete(graph.Deps, event.ID)
-			}
+	case EventHandoff:
+		var data HandoffEventData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		issue, ok := graph.Tasks[event.ID]
+		if !ok {
+			return nil
+		}
+		issue.Status = StatusInProgress
+		issue.Assignee = data.To
+		issue.Lease = leaseFrom(data.Lease, event.Timestamp)
+		issue.DeferUntil = nil
+		issue.UpdatedAt = event.Timestamp
+
+	case EventLeaseExpired, EventRelease:
+		issue, ok := graph.Tasks[event.ID]
+		if !ok {
+			return nil
+		}
+		issue.Status = StatusOpen
+		issue.Assignee = ""
+		issue.Lease = nil
+		issue.UpdatedAt = event.Timestamp
+
+	case EventDefer:
+		var data DeferEventData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		issue, ok := graph.Tasks[event.ID]
+		if !ok {
+			return nil
+		}
+		until := data.Until
+		issue.Status = StatusDeferred
+		issue.DeferUntil = &until
+		issue.UpdatedAt = event.Timestamp
 
-			graph.RDeps[data.DependsOnID] = removeString(graph.RDeps[data.DependsOnID], event.ID)
-			if len(graph.RDeps[data.DependsOnID]) == 0 {
-				delete(graph.RDeps, data.DependsOnID)
-			}
+	case EventUndefer:
+		issue, ok := graph.Tasks[event.ID]
+		if !ok {
+			return nil
+		}
+		issue.Status = StatusOpen
+		issue.DeferUntil = nil
+		issue.UpdatedAt = event.Timestamp
+
+	case EventComment:
+		var data CommentEventData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		issue, ok := graph.Tasks[event.ID]
+		if !ok {
+			return nil
+		}
+		issue.Comments = append(issue.Comments, &Comment{
+			ID:        data.CommentID,
+			IssueID:   event.ID,
+			Author:    event.Actor,
+			Text:      data.Text,
+			CreatedAt: event.Timestamp,
+			ReplyTo:   data.ReplyTo,
+		})
+		issue.UpdatedAt = event.Timestamp
+
+	case EventNote:
+		var data NoteEventData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		issue, ok := graph.Tasks[event.ID]
+		if !ok {
+			return nil
+		}
+		issue.Notes = appendNoteEntry(issue.Notes, event.Timestamp, event.Actor, data.Text)
+		issue.UpdatedAt = event.Timestamp
 
-			issue, ok := graph.Tasks[event.ID]
-			if !ok {
-				continue
+	case EventDepAdd:
+		var data DepAddEventData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		issue, ok := graph.Tasks[event.ID]
+		if hasDependency(graph, event.ID, data.DependsOnID) {
+			// Re-adding an existing edge changes its type/metadata in place.
+			if ok {
+				for _, dep := range issue.Dependencies {
+					if dep.DependsOnID == data.DependsOnID {
+						dep.Type = DependencyType(data.DepType)
+						dep.Metadata = data.Metadata
+					}
+				}
 			}
-			issue.Dependencies = removeDependency(issue.Dependencies, data.DependsOnID)
+			return nil
+		}
+		graph.Deps[event.ID] = append(graph.Deps[event.ID], data.DependsOnID)
+		graph.RDeps[data.DependsOnID] = append(graph.RDeps[data.DependsOnID], event.ID)
+		if !ok {
+			return nil
+		}
+		issue.Dependencies = append(issue.Dependencies, &Dependency{
+			IssueID:     event.ID,
+			DependsOnID: data.DependsOnID,
+			Type:        DependencyType(data.DepType),
+			CreatedAt:   event.Timestamp,
+			CreatedBy:   event.Actor,
+			Metadata:    data.Metadata,
+		})
+
+	case EventDepRemove:
+		var data DepRemoveEventData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return err
+		}
+		graph.Deps[event.ID] = removeString(graph.Deps[event.ID], data.DependsOnID)
+		if len(graph.Deps[event.ID]) == 0 {
+			delete(graph.Deps, event.ID)
+		}
 
-		default:
-			continue
+		graph.RDeps[data.DependsOnID] = removeString(graph.RDeps[data.DependsOnID], event.ID)
+		if len(graph.RDeps[data.DependsOnID]) == 0 {
+			delete(graph.RDeps, data.DependsOnID)
 		}
-	}
 
-	return graph, nil
+		issue, ok := graph.Tasks[event.ID]
+		if !ok {
+			return nil
+		}
+		issue.Dependencies = removeDependency(issue.Dependencies, data.DependsOnID)
+	}
+	return nil
 }
 
+// clearBlockingEdges drops edges pointing at a closed issue. Conditional edges
+// survive because their conditions may depend on how the blocker was closed.
 func clearBlockingEdges(graph *Graph, closedID string) {
 	blocked := append([]string(nil), graph.RDeps[closedID]...)
+	var kept []string
 	for _, blockedID := range blocked {
+		issue, ok := graph.Tasks[blockedID]
+		if ok && keepsConditionalEdge(issue, closedID) {
+			kept = append(kept, blockedID)
+			continue
+		}
 		graph.Deps[blockedID] = removeString(graph.Deps[blockedID], closedID)
 		if len(graph.Deps[blockedID]) == 0 {
 			delete(graph.Deps, blockedID)
 		}
-		issue, ok := graph.Tasks[blockedID]
 		if !ok {
 			continue
 		}
 		issue.Dependencies = removeDependency(issue.Dependencies, closedID)
 	}
-	delete(graph.RDeps, closedID)
+	if len(kept) == 0 {
+		delete(graph.RDeps, closedID)
+		return
+	}
+	graph.RDeps[closedID] = kept
+}
+
+// keepsConditionalEdge strips unconditional edges to closedID from issue and
+// reports whether a conditional one remains.
+func keepsConditionalEdge(issue *Issue, closedID string) bool {
+	kept := false
+	out := issue.Dependencies[:0]
+	for _, dep := range issue.Dependencies {
+		if dep == nil {
+			continue
+		}
+		if dep.DependsOnID == closedID && !dep.hasCondition() {
+			continue
+		}
+		if dep.DependsOnID == closedID {
+			kept = true
+		}
+		out = append(out, dep)
+	}
+	issue.Dependencies = out
+	return kept
+}
+
+// appendNoteEntry adds a "[time actor] text" entry on a new line after the
+// existing notes, so the accumulated text is also a plain beads notes field.
+func appendNoteEntry(notes string, at time.Time, actor, text string) string {
+	entry := fmt.Sprintf("[%s %s] %s", at.UTC().Format(time.RFC3339), actor, strings.TrimSpace(text))
+	notes = strings.TrimRight(notes, "\n")
+	if notes == "" {
+		return entry
+	}
+	return notes + "\n" + entry
 }
 
 func removeString(values []string, target string) []string {
@@ -397,7 +633,17 @@ func loadGraph(dir string) (*Graph, error) {
 	if err != nil {
 		return nil, err
 	}
-	return replayEvents(events)
+	graph, err := replayEvents(events)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachRemotes(graph, dir); err != nil {
+		return nil, err
+	}
+	// Commands read the blocked set from the index and keep it current as
+	// they apply events, rather than recomputing it per query.
+	graph.trackBlocked()
+	return graph, nil
 }
 
 func appendEventsToFile(path string, events []Event) error {
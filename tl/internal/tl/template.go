@@ -0,0 +1,463 @@
+// ABOUTME: Task templates — YAML/JSON files under .tl/templates/ describing a reusable subgraph of tasks.
+// ABOUTME: Loads and validates templates, substitutes {{variables}}, instantiates them and extracts them from epics.
+
+package tl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const templatesDirName = "templates"
+
+var (
+	templateVarPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]*)\s*\}\}`)
+	templateKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+)
+
+// Template describes an epic and the tasks beneath it. Text fields may
+// reference variables as {{name}}.
+type Template struct {
+	Name        string                      `yaml:"-" json:"-"`
+	Title       string                      `yaml:"title" json:"title"`
+	Description string                      `yaml:"description,omitempty" json:"description,omitempty"`
+	Priority    *int                        `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Labels      []string                    `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Variables   map[string]TemplateVariable `yaml:"variables,omitempty" json:"variables,omitempty"`
+	Tasks       []TemplateTask              `yaml:"tasks" json:"tasks"`
+}
+
+// TemplateVariable declares a variable. A variable without a default must
+// be supplied with --var.
+type TemplateVariable struct {
+	Description string  `yaml:"description,omitempty" json:"description,omitempty"`
+	Default     *string `yaml:"default,omitempty" json:"default,omitempty"`
+}
+
+// TemplateTask is one task in a template. Key names it within the template;
+// Parent (another task's key) nests it, otherwise it hangs off the epic.
+// BlockedBy lists keys of tasks that block it.
+type TemplateTask struct {
+	Key         string   `yaml:"key" json:"key"`
+	Title       string   `yaml:"title" json:"title"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Type        string   `yaml:"type,omitempty" json:"type,omitempty"`
+	Priority    *int     `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Labels      []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Estimate    int      `yaml:"estimate,omitempty" json:"estimate,omitempty"`
+	Parent      string   `yaml:"parent,omitempty" json:"parent,omitempty"`
+	BlockedBy   []string `yaml:"blocked_by,omitempty" json:"blocked_by,omitempty"`
+}
+
+func templatesDir(dir string) string {
+	return filepath.Join(dir, templatesDirName)
+}
+
+// loadTemplate finds <name>.yaml, .yml or .json under .tl/templates/.
+func loadTemplate(dir, name string) (*Template, error) {
+	for _, ext := range []string{".yaml", ".yml", ".json"} {
+		path := filepath.Join(templatesDir(dir), name+ext)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var tmpl Template
+		if ext == ".json" {
+			err = json.Unmarshal(data, &tmpl)
+		} else {
+			err = yaml.Unmarshal(data, &tmpl)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		tmpl.Name = name
+		if err := tmpl.validate(); err != nil {
+			return nil, validationError("%s: %w", path, err)
+		}
+		return &tmpl, nil
+	}
+	return nil, fmt.Errorf("template %q in %s: %w", name, templatesDir(dir), ErrNotFound)
+}
+
+// validate checks keys, references and that parent and blocks edges are acyclic.
+func (t *Template) validate() error {
+	if strings.TrimSpace(t.Title) == "" {
+		return errors.New("template has no title")
+	}
+	if len(t.Tasks) == 0 {
+		return errors.New("template has no tasks")
+	}
+	keys := make(map[string]bool, len(t.Tasks))
+	for _, task := range t.Tasks {
+		if !templateKeyPattern.MatchString(task.Key) {
+			return fmt.Errorf("task key %q must be lowercase letters, digits and dashes", task.Key)
+		}
+		if keys[task.Key] {
+			return fmt.Errorf("duplicate task key %q", task.Key)
+		}
+		if strings.TrimSpace(task.Title) == "" {
+			return fmt.Errorf("task %q has no title", task.Key)
+		}
+		keys[task.Key] = true
+	}
+	for _, task := range t.Tasks {
+		if task.Parent != "" && !keys[task.Parent] {
+			return fmt.Errorf("task %q: unknown parent %q", task.Key, task.Parent)
+		}
+		for _, blocker := range task.BlockedBy {
+			if !keys[blocker] {
+				return fmt.Errorf("task %q: unknown blocker %q", task.Key, blocker)
+			}
+		}
+	}
+	if _, err := t.order(); err != nil {
+		return err
+	}
+	for _, text := range t.texts() {
+		for _, match := range templateVarPattern.FindAllStringSubmatch(text, -1) {
+			if _, ok := t.Variables[match[1]]; !ok {
+				return fmt.Errorf("undeclared variable {{%s}}", match[1])
+			}
+		}
+	}
+	return nil
+}
+
+// order returns tasks so that every parent and blocker precedes its
+// dependents, failing with ErrCycle otherwise.
+func (t *Template) order() ([]TemplateTask, error) {
+	byKey := make(map[string]TemplateTask, len(t.Tasks))
+	for _, task := range t.Tasks {
+		byKey[task.Key] = task
+	}
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(t.Tasks))
+	ordered := make([]TemplateTask, 0, len(t.Tasks))
+	var visit func(key string, path []string) error
+	visit = func(key string, path []string) error {
+		switch state[key] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w in template: %s", ErrCycle, strings.Join(append(path, key), " -> "))
+		}
+		state[key] = visiting
+		task := byKey[key]
+		prereqs := append([]string(nil), task.BlockedBy...)
+		if task.Parent != "" {
+			prereqs = append(prereqs, task.Parent)
+		}
+		for _, prereq := range prereqs {
+			if err := visit(prereq, append(path, key)); err != nil {
+				return err
+			}
+		}
+		state[key] = done
+		ordered = append(ordered, task)
+		return nil
+	}
+	for _, task := range t.Tasks {
+		if err := visit(task.Key, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+func (t *Template) texts() []string {
+	texts := []string{t.Title, t.Description}
+	texts = append(texts, t.Labels...)
+	for _, task := range t.Tasks {
+		texts = append(texts, task.Title, task.Description)
+		texts = append(texts, task.Labels...)
+	}
+	return texts
+}
+
+// resolveVars merges supplied values with defaults; every declared variable
+// must end up with a value and every supplied one must be declared.
+func (t *Template) resolveVars(supplied map[string]string) (map[string]string, error) {
+	values := make(map[string]string, len(t.Variables))
+	for name := range supplied {
+		if _, ok := t.Variables[name]; !ok {
+			return nil, validationError("template %q has no variable %q", t.Name, name)
+		}
+	}
+	var missing []string
+	for name, variable := range t.Variables {
+		switch value, ok := supplied[name]; {
+		case ok:
+			values[name] = value
+		case variable.Default != nil:
+			values[name] = *variable.Default
+		default:
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, validationError("missing template variables: %s (use --var name=value)", strings.Join(missing, ", "))
+	}
+	return values, nil
+}
+
+func expandTemplateText(text string, values map[string]string) string {
+	return templateVarPattern.ReplaceAllStringFunc(text, func(match string) string {
+		return values[templateVarPattern.FindStringSubmatch(match)[1]]
+	})
+}
+
+func expandTemplateLabels(labels []string, values map[string]string) []string {
+	if len(labels) == 0 {
+		return nil
+	}
+	out := make([]string, len(labels))
+	for i, label := range labels {
+		out[i] = expandTemplateText(label, values)
+	}
+	return out
+}
+
+// templateInstance records which issue each template task became.
+type templateInstance struct {
+	Epic  Issue              `json:"epic"`
+	Tasks []templateInstTask `json:"tasks"`
+}
+
+type templateInstTask struct {
+	Key   string `json:"key"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// applyTemplate creates the epic, its tasks and their parent-child and
+// blocks edges in one mutate, so the subgraph appears all at once or not at all.
+func applyTemplate(dir string, tmpl *Template, supplied map[string]string) (templateInstance, error) {
+	var inst templateInstance
+	values, err := tmpl.resolveVars(supplied)
+	if err != nil {
+		return inst, err
+	}
+	ordered, err := tmpl.order()
+	if err != nil {
+		return inst, err
+	}
+
+	err = mutate(dir, func(g *Graph) ([]Event, error) {
+		var events []Event
+		apply := func(eventType, id string, data any) error {
+			evt, err := newEvent(eventType, id, data)
+			if err != nil {
+				return err
+			}
+			if err := g.applyEvent(evt); err != nil {
+				return err
+			}
+			events = append(events, evt)
+			return nil
+		}
+		newID := func() string {
+			id := generateID()
+			for g.Tasks[id] != nil {
+				id = generateID()
+			}
+			return id
+		}
+
+		epicID := newID()
+		epic := CreateEventData{
+			Title:       expandTemplateText(tmpl.Title, values),
+			Description: expandTemplateText(tmpl.Description, values),
+			Status:      string(StatusOpen),
+			Priority:    2,
+			IssueType:   string(TypeEpic),
+			Labels:      expandTemplateLabels(tmpl.Labels, values),
+		}
+		if tmpl.Priority != nil {
+			epic.Priority = *tmpl.Priority
+		}
+		if err := apply(EventCreate, epicID, epic); err != nil {
+			return nil, err
+		}
+
+		ids := make(map[string]string, len(ordered))
+		for _, task := range ordered {
+			data := CreateEventData{
+				Title:       expandTemplateText(task.Title, values),
+				Description: expandTemplateText(task.Description, values),
+				Status:      string(StatusOpen),
+				Priority:    epic.Priority,
+				IssueType:   task.Type,
+				Labels:      expandTemplateLabels(task.Labels, values),
+			}
+			if task.Priority != nil {
+				data.Priority = *task.Priority
+			}
+			if data.IssueType == "" {
+				data.IssueType = string(TypeTask)
+			}
+			if task.Estimate > 0 {
+				raw, err := json.Marshal(task.Estimate)
+				if err != nil {
+					return nil, err
+				}
+				data.Metadata = map[string]json.RawMessage{estimateMetadataKey: raw}
+			}
+
+			id := newID()
+			ids[task.Key] = id
+			if err := apply(EventCreate, id, data); err != nil {
+				return nil, err
+			}
+			parent := epicID
+			if task.Parent != "" {
+				parent = ids[task.Parent]
+			}
+			if err := apply(EventDepAdd, id, DepAddEventData{DependsOnID: parent, DepType: string(DepParentChild)}); err != nil {
+				return nil, err
+			}
+			for _, blocker := range task.BlockedBy {
+				if err := apply(EventDepAdd, id, DepAddEventData{DependsOnID: ids[blocker], DepType: string(DepBlocks)}); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		inst.Epic = *g.Tasks[epicID]
+		for _, task := range tmpl.Tasks {
+			id := ids[task.Key]
+			inst.Tasks = append(inst.Tasks, templateInstTask{Key: task.Key, ID: id, Title: g.Tasks[id].Title})
+		}
+		return events, nil
+	})
+	return inst, err
+}
+
+// extractTemplate builds a template from an epic's descendants, keeping
+// their nesting and the blocks edges among them. Edges are read from the
+// events because closing a task drops the edges pointing at it from the
+// graph, and a finished epic is the usual thing to extract. Variables are
+// left for the author to introduce.
+func extractTemplate(graph *Graph, events []Event, epicID string) (*Template, error) {
+	epic, ok := graph.Tasks[epicID]
+	if !ok {
+		return nil, fmt.Errorf("issue %q: %w", epicID, ErrNotFound)
+	}
+	outgoing := make(map[string][]contextEdge)
+	children := make(map[string][]string)
+	for _, edge := range loggedEdges(events) {
+		outgoing[edge.From] = append(outgoing[edge.From], edge)
+		if edge.Type == DepParentChild {
+			children[edge.To] = append(children[edge.To], edge.From)
+		}
+	}
+	members := make(map[string]bool)
+	for stack := children[epicID]; len(stack) > 0; {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, exists := graph.Tasks[id]; !exists || members[id] || id == epicID {
+			continue
+		}
+		members[id] = true
+		stack = append(stack, children[id]...)
+	}
+	if len(members) == 0 {
+		return nil, validationError("%s has no child tasks to extract", epicID)
+	}
+
+	issues := make([]*Issue, 0, len(members))
+	for id := range members {
+		issues = append(issues, graph.Tasks[id])
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		if !issues[i].CreatedAt.Equal(issues[j].CreatedAt) {
+			return issues[i].CreatedAt.Before(issues[j].CreatedAt)
+		}
+		return issues[i].ID < issues[j].ID
+	})
+
+	keys := make(map[string]string, len(issues))
+	used := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		key := templateKey(issue.Title)
+		for n := 2; used[key]; n++ {
+			key = fmt.Sprintf("%s-%d", templateKey(issue.Title), n)
+		}
+		used[key] = true
+		keys[issue.ID] = key
+	}
+
+	priority := epic.Priority
+	tmpl := &Template{
+		Title:       epic.Title,
+		Description: epic.Description,
+		Priority:    &priority,
+		Labels:      epic.Labels,
+	}
+	for _, issue := range issues {
+		taskPriority := issue.Priority
+		task := TemplateTask{
+			Key:         keys[issue.ID],
+			Title:       issue.Title,
+			Description: issue.Description,
+			Type:        string(issue.IssueType),
+			Priority:    &taskPriority,
+			Labels:      issue.Labels,
+		}
+		if _, ok := issue.Metadata[estimateMetadataKey]; ok {
+			task.Estimate = issueEstimate(issue)
+		}
+		for _, edge := range outgoing[issue.ID] {
+			key, inside := keys[edge.To]
+			switch {
+			case edge.Type == DepParentChild && inside && task.Parent == "":
+				task.Parent = key
+			case edge.Type == DepBlocks && inside:
+				task.BlockedBy = append(task.BlockedBy, key)
+			}
+		}
+		sort.Strings(task.BlockedBy)
+		tmpl.Tasks = append(tmpl.Tasks, task)
+	}
+	if err := tmpl.validate(); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// templateKey slugs a title into a task key.
+func templateKey(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if b.Len() > 0 && !dash {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	key := strings.TrimSuffix(b.String(), "-")
+	if len(key) > 40 {
+		key = strings.TrimSuffix(key[:40], "-")
+	}
+	if key == "" {
+		key = "task"
+	}
+	return key
+}
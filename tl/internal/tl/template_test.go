@@ -0,0 +1,232 @@
+// ABOUTME: Tests task templates: validation, variable substitution, atomic apply and extraction from an epic.
+// ABOUTME: Applying builds an epic with parent-child and blocks edges; extracting and re-applying round-trips the shape.
+
+package tl
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testReleaseTemplate = `title: "Release {{version}}"
+priority: 1
+labels: [release]
+variables:
+  version:
+    description: Version being released
+  branch:
+    default: main
+tasks:
+  - key: freeze
+    title: "Freeze {{branch}}"
+  - key: changelog
+    title: "Changelog for {{version}}"
+    type: chore
+    estimate: 30
+    blocked_by: [freeze]
+  - key: tag
+    title: "Tag v{{version}}"
+    priority: 0
+    labels: ["v{{version}}"]
+    blocked_by: [freeze, changelog]
+  - key: announce
+    title: Announce
+    parent: tag
+`
+
+func writeTemplate(t *testing.T, dir, name, body string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(templatesDir(dir), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(templatesDir(dir), name), []byte(body), 0644))
+}
+
+func blockerIDs(issue *Issue) []string {
+	var out []string
+	for _, dep := range issue.Dependencies {
+		if dep.Type == DepBlocks {
+			out = append(out, dep.DependsOnID)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestLoadTemplateValidates(t *testing.T) {
+	dir := seedCommandRepoWithEvents(t)
+	writeTemplate(t, dir, "release.yaml", testReleaseTemplate)
+	tmpl, err := loadTemplate(dir, "release")
+	require.NoError(t, err)
+	assert.Equal(t, "release", tmpl.Name)
+	assert.Len(t, tmpl.Tasks, 4)
+
+	writeTemplate(t, dir, "json.json", `{"title": "J", "tasks": [{"key": "a", "title": "A"}]}`)
+	_, err = loadTemplate(dir, "json")
+	require.NoError(t, err)
+
+	_, err = loadTemplate(dir, "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	for body, want := range map[string]string{
+		"title: T\ntasks: []\n": "no tasks",
+		"title: T\ntasks:\n  - {key: a, title: A}\n  - {key: a, title: B}\n":                                   "duplicate task key",
+		"title: T\ntasks:\n  - {key: a, title: A, blocked_by: [b]}\n":                                          "unknown blocker",
+		"title: T\ntasks:\n  - {key: a, title: A, parent: b}\n":                                                "unknown parent",
+		"title: T\ntasks:\n  - {key: a, title: A, blocked_by: [b]}\n  - {key: b, title: B, blocked_by: [a]}\n": "cycle",
+		"title: \"T {{x}}\"\ntasks:\n  - {key: a, title: A}\n":                                                 "undeclared variable {{x}}",
+		"title: T\ntasks:\n  - {key: A B, title: A}\n":                                                         "lowercase",
+	} {
+		writeTemplate(t, dir, "bad.yaml", body)
+		_, err := loadTemplate(dir, "bad")
+		assert.ErrorContains(t, err, want, body)
+	}
+}
+
+func TestApplyTemplateCreatesSubgraph(t *testing.T) {
+	dir := seedCommandRepoWithEvents(t)
+	writeTemplate(t, dir, "release.yaml", testReleaseTemplate)
+	tmpl, err := loadTemplate(dir, "release")
+	require.NoError(t, err)
+
+	_, err = applyTemplate(dir, tmpl, nil)
+	assert.ErrorContains(t, err, "missing template variables: version")
+	_, err = applyTemplate(dir, tmpl, map[string]string{"version": "1.4", "colour": "red"})
+	assert.ErrorContains(t, err, `has no variable "colour"`)
+
+	inst, err := applyTemplate(dir, tmpl, map[string]string{"version": "1.4"})
+	require.NoError(t, err)
+	assert.Equal(t, "Release 1.4", inst.Epic.Title)
+	assert.Equal(t, TypeEpic, inst.Epic.IssueType)
+	require.Len(t, inst.Tasks, 4)
+	ids := make(map[string]string)
+	for _, task := range inst.Tasks {
+		ids[task.Key] = task.ID
+	}
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.Len(t, graph.Tasks, 5)
+
+	freeze, changelog, tag, announce := graph.Tasks[ids["freeze"]], graph.Tasks[ids["changelog"]], graph.Tasks[ids["tag"]], graph.Tasks[ids["announce"]]
+	assert.Equal(t, "Freeze main", freeze.Title, "defaults fill unsupplied variables")
+	assert.Equal(t, 1, freeze.Priority, "tasks inherit the epic priority")
+	assert.Equal(t, TypeTask, freeze.IssueType)
+	assert.Equal(t, TypeChore, changelog.IssueType)
+	assert.Equal(t, 30, issueEstimate(changelog))
+	assert.Equal(t, 0, tag.Priority)
+	assert.Equal(t, []string{"v1.4"}, tag.Labels)
+
+	assert.Equal(t, []string{inst.Epic.ID}, parentIDs(freeze))
+	assert.Equal(t, []string{ids["tag"]}, parentIDs(announce))
+	assert.Equal(t, []string{ids["freeze"]}, blockerIDs(changelog))
+	expected := []string{ids["changelog"], ids["freeze"]}
+	sort.Strings(expected)
+	assert.Equal(t, expected, blockerIDs(tag))
+
+	var ready []string
+	for _, issue := range collectReadyIssues(graph, computeBlockedSet(graph), time.Now()) {
+		ready = append(ready, issue.ID)
+	}
+	assert.Equal(t, []string{ids["freeze"]}, ready, "tasks without blocked_by are ready; the epic waits for them")
+}
+
+func TestTemplateExtractRoundTrips(t *testing.T) {
+	dir := seedCommandRepoWithEvents(t)
+	writeTemplate(t, dir, "release.yaml", testReleaseTemplate)
+	tmpl, err := loadTemplate(dir, "release")
+	require.NoError(t, err)
+	inst, err := applyTemplate(dir, tmpl, map[string]string{"version": "2.0"})
+	require.NoError(t, err)
+
+	setCommandGlobals(t, dir, false)
+	prevName, prevForce := templateExtractName, templateExtractForce
+	t.Cleanup(func() { templateExtractName, templateExtractForce = prevName, prevForce })
+	templateExtractName, templateExtractForce = "", false
+
+	cmd := newTestCommand()
+	require.NoError(t, runTemplateExtract(cmd, []string{inst.Epic.ID}))
+	assert.Contains(t, cmd.OutOrStdout().(*bytes.Buffer).String(), "Wrote template release-2-0 to ")
+	assert.ErrorContains(t, runTemplateExtract(newTestCommand(), []string{inst.Epic.ID}), "already exists")
+
+	extracted, err := loadTemplate(dir, "release-2-0")
+	require.NoError(t, err)
+	assert.Equal(t, "Release 2.0", extracted.Title)
+	byKey := make(map[string]TemplateTask)
+	for _, task := range extracted.Tasks {
+		byKey[task.Key] = task
+	}
+	require.Len(t, byKey, 4)
+	assert.Equal(t, []string{"freeze-main"}, byKey["changelog-for-2-0"].BlockedBy)
+	assert.Equal(t, []string{"changelog-for-2-0", "freeze-main"}, byKey["tag-v2-0"].BlockedBy)
+	assert.Equal(t, "tag-v2-0", byKey["announce"].Parent)
+	assert.Equal(t, 30, byKey["changelog-for-2-0"].Estimate)
+
+	_, err = applyTemplate(dir, extracted, nil)
+	require.NoError(t, err)
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.Len(t, graph.Tasks, 10)
+
+	assert.ErrorIs(t, runTemplateExtract(newTestCommand(), []string{"tl-nope"}), ErrNotFound)
+}
+
+func TestTemplateExtractKeepsEdgesToClosedTasks(t *testing.T) {
+	dir := seedCommandRepoWithEvents(t)
+	writeTemplate(t, dir, "release.yaml", testReleaseTemplate)
+	tmpl, err := loadTemplate(dir, "release")
+	require.NoError(t, err)
+	inst, err := applyTemplate(dir, tmpl, map[string]string{"version": "2.0"})
+	require.NoError(t, err)
+	extract := func() (*Template, error) {
+		events, err := readEvents(filepath.Join(dir, eventsFileName))
+		require.NoError(t, err)
+		graph, err := replayEvents(events)
+		require.NoError(t, err)
+		return extractTemplate(graph, events, inst.Epic.ID)
+	}
+
+	setCommandGlobals(t, dir, false)
+	for _, task := range inst.Tasks[:2] {
+		require.NoError(t, runClose(newCloseCommand(t, nil), []string{task.ID}))
+	}
+	partial, err := extract()
+	require.NoError(t, err)
+	require.Len(t, partial.Tasks, 4)
+	assert.Equal(t, []string{"freeze-main"}, partial.Tasks[1].BlockedBy, "a closed blocker stays in the template")
+
+	for _, task := range inst.Tasks[2:] {
+		require.NoError(t, runClose(newCloseCommand(t, map[string]string{"cascade": "true"}), []string{task.ID}))
+	}
+	require.NoError(t, runClose(newCloseCommand(t, nil), []string{inst.Epic.ID}))
+	closed, err := extract()
+	require.NoError(t, err)
+	assert.Equal(t, partial.Tasks, closed.Tasks, "a finished epic extracts its full shape")
+
+	lone := seedCommandRepoWithEvents(t, createIssueEvent(t, "tl-lone", "Lone", StatusOpen, 1, time.Now()))
+	setCommandGlobals(t, lone, false)
+	assert.ErrorIs(t, runTemplateExtract(newTestCommand(), []string{"tl-lone"}), ErrValidation)
+}
+
+func TestTemplateApplyCommand(t *testing.T) {
+	dir := seedCommandRepoWithEvents(t)
+	writeTemplate(t, dir, "release.yaml", testReleaseTemplate)
+	setCommandGlobals(t, dir, false)
+	prev := templateVars
+	t.Cleanup(func() { templateVars = prev })
+
+	templateVars = []string{"version"}
+	assert.ErrorContains(t, runTemplateApply(newTestCommand(), []string{"release"}), "want name=value")
+
+	templateVars = []string{"version=1.4"}
+	cmd := newTestCommand()
+	require.NoError(t, runTemplateApply(cmd, []string{"release"}))
+	out := cmd.OutOrStdout().(*bytes.Buffer).String()
+	assert.Contains(t, out, ": Release 1.4 (4 tasks from template release)\n")
+	assert.Contains(t, out, " tag: Tag v1.4\n")
+}
@@ -0,0 +1,300 @@
+// ABOUTME: Live event stream behind `tl watch`: tails events.jsonl and folds each new event into a graph.
+// ABOUTME: Emits event records plus derived became_ready/became_blocked notifications, filtered by id, type, label and actor.
+
+package tl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Watch record kinds. Event records mirror the log; the others are derived by
+// diffing the ready and blocked sets before and after each event.
+const (
+	watchKindEvent         = "event"
+	watchKindBecameReady   = "became_ready"
+	watchKindBecameBlocked = "became_blocked"
+)
+
+const defaultWatchInterval = time.Second
+
+// watchRecord is one JSON line of `tl watch` output. Event is the logged
+// event, or for derived records the event that caused the change; it is nil
+// when the change came from time passing (a lease expiring, a deferral lapsing).
+type watchRecord struct {
+	Kind  string    `json:"kind"`
+	ID    string    `json:"id"`
+	At    time.Time `json:"at"`
+	Event *Event    `json:"event,omitempty"`
+	Issue *Issue    `json:"issue,omitempty"`
+}
+
+// watchOptions selects which records are emitted. Empty filters match
+// everything; Type matches either a record kind or an event type.
+type watchOptions struct {
+	ID        string
+	Type      string
+	Label     string
+	Actor     string
+	WithIssue bool
+	Interval  time.Duration
+	Poll      bool
+}
+
+func (opts watchOptions) matches(rec watchRecord, issue *Issue) bool {
+	if opts.ID != "" && rec.ID != opts.ID {
+		return false
+	}
+	if opts.Type != "" && opts.Type != rec.Kind && (rec.Event == nil || rec.Event.Type != opts.Type) {
+		return false
+	}
+	if opts.Label != "" && (issue == nil || !containsString(issue.Labels, opts.Label)) {
+		return false
+	}
+	if opts.Actor != "" && (rec.Event == nil || rec.Event.Actor != opts.Actor) {
+		return false
+	}
+	return true
+}
+
+// eventWatcher tails the events log from a byte offset. Only complete lines
+// are applied; a trailing partial write is held until its newline arrives.
+type eventWatcher struct {
+	dir     string
+	path    string
+	opts    watchOptions
+	offset  int64
+	partial []byte
+	graph   *Graph
+	ready   map[string]bool
+	blocked map[string]bool
+}
+
+// newEventWatcher starts at the current end of the log, so only events
+// appended afterwards are reported.
+func newEventWatcher(dir string, opts watchOptions, now time.Time) (*eventWatcher, error) {
+	w := &eventWatcher{dir: dir, path: filepath.Join(dir, eventsFileName), opts: opts}
+	if err := w.reset(now); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// reset rebuilds the graph from the whole log. Cross-repo dependency targets
+// are resolved here, once, rather than on every event.
+func (w *eventWatcher) reset(now time.Time) error {
+	w.offset, w.partial = 0, nil
+	events, _, err := w.readNew()
+	if err != nil {
+		return err
+	}
+	graph, err := replayEvents(events)
+	if err != nil {
+		return err
+	}
+	if err := attachRemotes(graph, w.dir); err != nil {
+		return err
+	}
+	graph.trackBlocked()
+	w.graph = graph
+	w.ready, w.blocked = w.readiness(now)
+	return nil
+}
+
+// readNew returns the complete events appended since the last read. rewritten
+// reports that the log shrank underneath us and the watcher must reset.
+func (w *eventWatcher) readNew() (events []Event, rewritten bool, err error) {
+	file, err := os.Open(w.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, w.offset > 0, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, false, err
+	}
+	if info.Size() < w.offset {
+		return nil, true, nil
+	}
+	if _, err := file.Seek(w.offset, io.SeekStart); err != nil {
+		return nil, false, err
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, false, err
+	}
+	w.offset += int64(len(data))
+
+	buf := append(w.partial, data...)
+	end := bytes.LastIndexByte(buf, '\n')
+	if end < 0 {
+		w.partial = buf
+		return nil, false, nil
+	}
+	w.partial = append([]byte(nil), buf[end+1:]...)
+	for _, line := range bytes.Split(buf[:end], []byte{'\n'}) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal(line, &event); err != nil {
+			return nil, false, fmt.Errorf("%s: invalid JSON in events log: %w", w.path, err)
+		}
+		events = append(events, event)
+	}
+	return events, false, nil
+}
+
+// poll applies everything appended since the last call and returns the
+// records that pass the filters, in log order.
+func (w *eventWatcher) poll(now time.Time) ([]watchRecord, error) {
+	events, rewritten, err := w.readNew()
+	if err != nil {
+		return nil, err
+	}
+	if rewritten {
+		return nil, w.reset(now)
+	}
+
+	out := w.derive(nil, nil, now)
+	for i := range events {
+		event := events[i]
+		if err := w.graph.applyEvent(event); err != nil {
+			return out, err
+		}
+		out = w.keep(out, watchRecord{Kind: watchKindEvent, ID: event.ID, At: event.Timestamp, Event: &event})
+		out = w.derive(out, &event, now)
+	}
+	return out, nil
+}
+
+// derive appends a record for every issue that entered the ready or blocked
+// set since the last call, then remembers the new sets.
+func (w *eventWatcher) derive(out []watchRecord, cause *Event, now time.Time) []watchRecord {
+	ready, blocked := w.readiness(now)
+	at := now
+	if cause != nil {
+		at = cause.Timestamp
+	}
+	for _, id := range newlyAdded(w.ready, ready) {
+		out = w.keep(out, watchRecord{Kind: watchKindBecameReady, ID: id, At: at, Event: cause})
+	}
+	for _, id := range newlyAdded(w.blocked, blocked) {
+		out = w.keep(out, watchRecord{Kind: watchKindBecameBlocked, ID: id, At: at, Event: cause})
+	}
+	w.ready, w.blocked = ready, blocked
+	return out
+}
+
+// readiness returns the ready queue and the blocked open work as ID sets.
+// Closed issues stay in the blocked index but are not reported as blocked.
+func (w *eventWatcher) readiness(now time.Time) (ready, blocked map[string]bool) {
+	blockedSet := w.graph.blocked.blocked
+	ready = make(map[string]bool)
+	for _, issue := range collectReadyIssues(w.graph, blockedSet, now) {
+		ready[issue.ID] = true
+	}
+	blocked = make(map[string]bool, len(blockedSet))
+	for id := range blockedSet {
+		if issue, ok := w.graph.Tasks[id]; ok && issue.Status != StatusClosed {
+			blocked[id] = true
+		}
+	}
+	return ready, blocked
+}
+
+func (w *eventWatcher) keep(out []watchRecord, rec watchRecord) []watchRecord {
+	issue := w.graph.Tasks[rec.ID]
+	if !w.opts.matches(rec, issue) {
+		return out
+	}
+	if w.opts.WithIssue && issue != nil {
+		rec.Issue = cloneIssue(issue)
+	}
+	return append(out, rec)
+}
+
+func newlyAdded(before, after map[string]bool) []string {
+	var ids []string
+	for id := range after {
+		if !before[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// changeNotifier blocks until the .tl directory may have changed or timeout
+// elapses, whichever comes first. Spurious wake-ups are fine: the watcher
+// simply finds nothing new.
+type changeNotifier interface {
+	wait(ctx context.Context, timeout time.Duration) error
+	Close() error
+}
+
+// pollNotifier is the portable fallback: it just sleeps for the interval.
+type pollNotifier struct{}
+
+func (pollNotifier) wait(ctx context.Context, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (pollNotifier) Close() error { return nil }
+
+// watchEvents streams records to emit until ctx is cancelled. The interval
+// bounds how long a change can go unnoticed without inotify, and how late
+// time-driven notifications such as lease expiry are reported.
+func watchEvents(ctx context.Context, dir string, opts watchOptions, emit func(watchRecord) error) error {
+	if opts.Interval <= 0 {
+		opts.Interval = defaultWatchInterval
+	}
+	var notifier changeNotifier = pollNotifier{}
+	if !opts.Poll {
+		notifier = newChangeNotifier(dir)
+	}
+	defer notifier.Close()
+
+	watcher, err := newEventWatcher(dir, opts, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for {
+		if err := notifier.wait(ctx, opts.Interval); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		records, err := watcher.poll(time.Now())
+		for _, rec := range records {
+			if emitErr := emit(rec); emitErr != nil {
+				return emitErr
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
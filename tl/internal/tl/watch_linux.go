@@ -0,0 +1,75 @@
+// ABOUTME: inotify-backed change notification for `tl watch` on Linux.
+// ABOUTME: Watches the .tl directory and falls back to polling when inotify is unavailable.
+
+//go:build linux
+
+package tl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// inotifyPollSlice caps each poll(2) call so cancellation is noticed promptly.
+const inotifyPollSlice = 200 * time.Millisecond
+
+type inotifyNotifier struct {
+	fd int
+}
+
+// newChangeNotifier watches the directory rather than events.jsonl itself so
+// the watch survives the log being replaced.
+func newChangeNotifier(dir string) changeNotifier {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return pollNotifier{}
+	}
+	if _, err := unix.InotifyAddWatch(fd, dir, unix.IN_MODIFY|unix.IN_CREATE|unix.IN_MOVED_TO); err != nil {
+		unix.Close(fd)
+		return pollNotifier{}
+	}
+	return &inotifyNotifier{fd: fd}
+}
+
+func (n *inotifyNotifier) wait(ctx context.Context, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil
+		}
+		slice := max(min(remaining, inotifyPollSlice), time.Millisecond)
+		fds := []unix.PollFd{{Fd: int32(n.fd), Events: unix.POLLIN}}
+		count, err := unix.Poll(fds, int(slice/time.Millisecond))
+		if errors.Is(err, unix.EINTR) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			n.drain()
+			return nil
+		}
+	}
+}
+
+// drain discards queued inotify records; the watcher rereads the log anyway.
+func (n *inotifyNotifier) drain() {
+	buf := make([]byte, 4096)
+	for {
+		if count, err := unix.Read(n.fd, buf); err != nil || count <= 0 {
+			return
+		}
+	}
+}
+
+func (n *inotifyNotifier) Close() error {
+	return unix.Close(n.fd)
+}
@@ -0,0 +1,10 @@
+// ABOUTME: Change notification for `tl watch` on platforms without inotify.
+// ABOUTME: Always polls the events log at the watch interval.
+
+//go:build !linux
+
+package tl
+
+func newChangeNotifier(dir string) changeNotifier {
+	return pollNotifier{}
+}
@@ -0,0 +1,171 @@
+// ABOUTME: Tests `tl watch`: tailing the log, partial lines, filters and derived readiness notifications.
+// ABOUTME: Drives the watcher by polling directly, plus one end-to-end run through watchEvents.
+
+package tl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func kinds(records []watchRecord) []string {
+	out := make([]string, 0, len(records))
+	for _, rec := range records {
+		out = append(out, rec.Kind+" "+rec.ID)
+	}
+	return out
+}
+
+func TestWatchDerivesReadinessChanges(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	dir := seedCommandRepoWithEvents(t,
+		createIssueEvent(t, "tl-blocker", "Blocker", StatusOpen, 1, ts),
+		createIssueEvent(t, "tl-child", "Child", StatusOpen, 1, ts.Add(time.Minute)),
+	)
+	path := filepath.Join(dir, eventsFileName)
+	watcher, err := newEventWatcher(dir, watchOptions{}, ts)
+	require.NoError(t, err)
+
+	records, err := watcher.poll(ts)
+	require.NoError(t, err)
+	assert.Empty(t, records, "existing events are not replayed")
+
+	require.NoError(t, appendEventsToFile(path, []Event{depAddEvent(t, "tl-child", "tl-blocker", DepBlocks, ts.Add(2*time.Minute))}))
+	records, err = watcher.poll(ts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"event tl-child", "became_blocked tl-child"}, kinds(records))
+	assert.Equal(t, EventDepAdd, records[1].Event.Type, "derived records carry their cause")
+
+	require.NoError(t, appendEventsToFile(path, []Event{
+		closeIssueEvent(t, "tl-blocker", "done", ts.Add(3*time.Minute)),
+		createIssueEvent(t, "tl-new", "New", StatusOpen, 2, ts.Add(4*time.Minute)),
+	}))
+	records, err = watcher.poll(ts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"event tl-blocker", "became_ready tl-child", "event tl-new", "became_ready tl-new"}, kinds(records))
+}
+
+func TestWatchHoldsPartialLines(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	dir := seedCommandRepoWithEvents(t)
+	path := filepath.Join(dir, eventsFileName)
+	watcher, err := newEventWatcher(dir, watchOptions{Type: watchKindEvent}, ts)
+	require.NoError(t, err)
+
+	tmp := filepath.Join(t.TempDir(), "events.jsonl")
+	require.NoError(t, appendEventsToFile(tmp, []Event{createIssueEvent(t, "tl-a", "A", StatusOpen, 1, ts)}))
+	line, err := os.ReadFile(tmp)
+	require.NoError(t, err)
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	defer file.Close()
+	_, err = file.Write(line[:10])
+	require.NoError(t, err)
+	records, err := watcher.poll(ts)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	_, err = file.Write(line[10:])
+	require.NoError(t, err)
+	records, err = watcher.poll(ts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"event tl-a"}, kinds(records))
+}
+
+func TestWatchFiltersAndIssueState(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	dir := seedCommandRepoWithEvents(t,
+		labeledIssueEvent(t, "tl-ui", "UI", TypeTask, 1, []string{"ui"}, ts),
+		createIssueEvent(t, "tl-db", "DB", StatusOpen, 1, ts),
+	)
+	path := filepath.Join(dir, eventsFileName)
+	events := []Event{
+		claimEventAt(t, "tl-ui", "agent-1", "", ts.Add(time.Minute)),
+		claimEventAt(t, "tl-db", "agent-2", "", ts.Add(2*time.Minute)),
+		closeIssueEvent(t, "tl-ui", "done", ts.Add(3*time.Minute)),
+	}
+
+	cases := []struct {
+		opts watchOptions
+		want []string
+	}{
+		{watchOptions{ID: "tl-db"}, []string{"event tl-db"}},
+		{watchOptions{Type: EventClaim}, []string{"event tl-ui", "event tl-db"}},
+		{watchOptions{Label: "ui"}, []string{"event tl-ui", "event tl-ui"}},
+		{watchOptions{Actor: "agent-2"}, []string{"event tl-db"}},
+	}
+	watchers := make([]*eventWatcher, len(cases))
+	for i, tc := range cases {
+		var err error
+		watchers[i], err = newEventWatcher(dir, tc.opts, ts)
+		require.NoError(t, err)
+	}
+	require.NoError(t, appendEventsToFile(path, events))
+	for i, tc := range cases {
+		records, err := watchers[i].poll(ts)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, kinds(records), "%+v", tc.opts)
+	}
+
+	watcher, err := newEventWatcher(dir, watchOptions{WithIssue: true}, ts)
+	require.NoError(t, err)
+	require.NoError(t, appendEventsToFile(path, []Event{closeIssueEvent(t, "tl-db", "done", ts.Add(4*time.Minute))}))
+	records, err := watcher.poll(ts)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.NotNil(t, records[0].Issue)
+	assert.Equal(t, StatusClosed, records[0].Issue.Status, "issue state is taken after the event")
+}
+
+func TestWatchReportsLapsedDeferral(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	dir := seedCommandRepoWithEvents(t,
+		createIssueEvent(t, "tl-later", "Later", StatusOpen, 1, ts),
+		deferEventAt(t, "tl-later", ts.Add(time.Hour), ts),
+	)
+	watcher, err := newEventWatcher(dir, watchOptions{}, ts)
+	require.NoError(t, err)
+
+	records, err := watcher.poll(ts.Add(2 * time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []string{"became_ready tl-later"}, kinds(records))
+	assert.Nil(t, records[0].Event, "time-driven changes have no causing event")
+}
+
+func TestWatchEventsStreamsUntilCancelled(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	for _, poll := range []bool{false, true} {
+		dir := seedCommandRepoWithEvents(t, createIssueEvent(t, "tl-a", "A", StatusOpen, 1, ts))
+		ctx, cancel := context.WithCancel(context.Background())
+		var mu sync.Mutex
+		var got []watchRecord
+		done := make(chan error, 1)
+		go func() {
+			done <- watchEvents(ctx, dir, watchOptions{Type: watchKindEvent, Interval: 20 * time.Millisecond, Poll: poll}, func(rec watchRecord) error {
+				mu.Lock()
+				defer mu.Unlock()
+				got = append(got, rec)
+				return nil
+			})
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		require.NoError(t, appendEventsToFile(filepath.Join(dir, eventsFileName), []Event{closeIssueEvent(t, "tl-a", "done", ts)}))
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(got) == 1
+		}, 2*time.Second, 10*time.Millisecond)
+		cancel()
+		require.NoError(t, <-done)
+		assert.Equal(t, EventClose, got[0].Event.Type, "poll=%v", poll)
+	}
+}
@@ -0,0 +1,137 @@
+// ABOUTME: Work-in-progress limits per assignee, per label and globally, configured under wip: in config.yaml.
+// ABOUTME: checkWIP guards transitions into in_progress; wipUsage reports current counts for tl stats.
+
+package tl
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// WIP limit scopes.
+const (
+	wipScopeGlobal   = "global"
+	wipScopeAssignee = "assignee"
+	wipScopeLabel    = "label"
+)
+
+// WIPLimitError reports the limit a transition into in_progress would exceed.
+type WIPLimitError struct {
+	Scope   string `json:"scope"`
+	Key     string `json:"key,omitempty"`
+	Limit   int    `json:"limit"`
+	Current int    `json:"current"`
+}
+
+func (e *WIPLimitError) Error() string {
+	subject := e.Scope
+	if e.Key != "" {
+		subject += " " + e.Key
+	}
+	return fmt.Sprintf("%s: %s has %d of %d tasks in progress", ErrWIPLimit.Error(), subject, e.Current, e.Limit)
+}
+
+func (e *WIPLimitError) Unwrap() error {
+	return ErrWIPLimit
+}
+
+// wipUsage is the current in_progress count against one configured limit.
+type wipUsage struct {
+	Scope   string `json:"scope"`
+	Key     string `json:"key,omitempty"`
+	Limit   int    `json:"limit"`
+	Current int    `json:"current"`
+}
+
+// countsAsWIP reports whether issue occupies a WIP slot. A lapsed lease
+// frees the slot, since anyone may reclaim the task.
+func countsAsWIP(issue *Issue, now time.Time) bool {
+	return issue.Status == StatusInProgress && !leaseExpired(issue, now)
+}
+
+// checkWIP fails with a *WIPLimitError when moving issue into in_progress
+// for assignee, or handing it to assignee while in progress, would exceed a
+// limit. The issue itself is not counted, so re-claims and handoffs of an
+// in-progress task are judged fairly.
+func checkWIP(graph *Graph, limits WIPConfig, issue *Issue, assignee string, now time.Time) error {
+	if limits.Global <= 0 && limits.PerAssignee <= 0 && len(limits.Labels) == 0 {
+		return nil
+	}
+
+	global, byAssignee := 0, 0
+	byLabel := make(map[string]int)
+	for id, other := range graph.Tasks {
+		if id == issue.ID || !countsAsWIP(other, now) {
+			continue
+		}
+		global++
+		if assignee != "" && other.Assignee == assignee {
+			byAssignee++
+		}
+		for _, label := range other.Labels {
+			byLabel[label]++
+		}
+	}
+
+	if limits.Global > 0 && global >= limits.Global {
+		return &WIPLimitError{Scope: wipScopeGlobal, Limit: limits.Global, Current: global}
+	}
+	if limits.PerAssignee > 0 && assignee != "" && byAssignee >= limits.PerAssignee {
+		return &WIPLimitError{Scope: wipScopeAssignee, Key: assignee, Limit: limits.PerAssignee, Current: byAssignee}
+	}
+	labels := append([]string(nil), issue.Labels...)
+	sort.Strings(labels)
+	for _, label := range labels {
+		if limit, ok := limits.Labels[label]; ok && limit > 0 && byLabel[label] >= limit {
+			return &WIPLimitError{Scope: wipScopeLabel, Key: label, Limit: limit, Current: byLabel[label]}
+		}
+	}
+	return nil
+}
+
+// computeWIPUsage lists every configured limit with its current count: the global
+// limit, one row per assignee holding work, and one row per limited label.
+func computeWIPUsage(graph *Graph, limits WIPConfig, now time.Time) []wipUsage {
+	global := 0
+	byAssignee := make(map[string]int)
+	byLabel := make(map[string]int)
+	for _, issue := range graph.Tasks {
+		if !countsAsWIP(issue, now) {
+			continue
+		}
+		global++
+		if issue.Assignee != "" {
+			byAssignee[issue.Assignee]++
+		}
+		for _, label := range issue.Labels {
+			byLabel[label]++
+		}
+	}
+
+	var rows []wipUsage
+	if limits.Global > 0 {
+		rows = append(rows, wipUsage{Scope: wipScopeGlobal, Limit: limits.Global, Current: global})
+	}
+	if limits.PerAssignee > 0 {
+		assignees := make([]string, 0, len(byAssignee))
+		for assignee := range byAssignee {
+			assignees = append(assignees, assignee)
+		}
+		sort.Strings(assignees)
+		for _, assignee := range assignees {
+			rows = append(rows, wipUsage{Scope: wipScopeAssignee, Key: assignee, Limit: limits.PerAssignee, Current: byAssignee[assignee]})
+		}
+	}
+	labels := make([]string, 0, len(limits.Labels))
+	for label, limit := range limits.Labels {
+		if limit > 0 {
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		rows = append(rows, wipUsage{Scope: wipScopeLabel, Key: label, Limit: limits.Labels[label], Current: byLabel[label]})
+	}
+	return rows
+}
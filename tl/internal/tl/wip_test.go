@@ -0,0 +1,185 @@
+// ABOUTME: Tests work-in-progress limits per assignee, per label and globally.
+// ABOUTME: Covers checkWIP, enforcement in claim, update and edit, the JSON error and tl stats usage.
+
+package tl
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWIPConfig = `wip:
+  global: 3
+  per_assignee: 1
+  labels:
+    area:db: 1
+`
+
+// seedWIPRepo has alice holding one area:db task, with two more db tasks
+// and a docs task open.
+func seedWIPRepo(t *testing.T) string {
+	t.Helper()
+	ts := time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)
+	dir := seedCommandRepoWithEvents(t,
+		labeledIssueEvent(t, "tl-db1", "Migrate users", TypeTask, 1, []string{"area:db"}, ts),
+		labeledIssueEvent(t, "tl-db2", "Add index", TypeTask, 1, []string{"area:db"}, ts.Add(time.Minute)),
+		labeledIssueEvent(t, "tl-doc", "Write guide", TypeTask, 2, []string{"docs"}, ts.Add(2*time.Minute)),
+		labeledIssueEvent(t, "tl-doc2", "Fix typos", TypeTask, 3, nil, ts.Add(3*time.Minute)),
+		claimEventAt(t, "tl-db1", "alice", "", ts.Add(4*time.Minute)),
+	)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, configFileName), []byte(testWIPConfig), 0644))
+	return dir
+}
+
+func TestCheckWIP(t *testing.T) {
+	dir := seedWIPRepo(t)
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	cfg, err := loadConfig(dir)
+	require.NoError(t, err)
+	now := time.Now()
+
+	err = checkWIP(graph, cfg.WIP, graph.Tasks["tl-doc"], "alice", now)
+	var wipErr *WIPLimitError
+	require.ErrorAs(t, err, &wipErr)
+	assert.ErrorIs(t, err, ErrWIPLimit)
+	assert.Equal(t, WIPLimitError{Scope: wipScopeAssignee, Key: "alice", Limit: 1, Current: 1}, *wipErr)
+	assert.Equal(t, "work-in-progress limit reached: assignee alice has 1 of 1 tasks in progress", err.Error())
+
+	err = checkWIP(graph, cfg.WIP, graph.Tasks["tl-db2"], "bob", now)
+	require.ErrorAs(t, err, &wipErr)
+	assert.Equal(t, WIPLimitError{Scope: wipScopeLabel, Key: "area:db", Limit: 1, Current: 1}, *wipErr)
+
+	assert.NoError(t, checkWIP(graph, cfg.WIP, graph.Tasks["tl-doc"], "bob", now))
+	assert.NoError(t, checkWIP(graph, cfg.WIP, graph.Tasks["tl-db1"], "alice", now), "the held task does not count against itself")
+
+	err = checkWIP(graph, WIPConfig{Global: 1}, graph.Tasks["tl-doc"], "", now)
+	require.ErrorAs(t, err, &wipErr)
+	assert.Equal(t, wipScopeGlobal, wipErr.Scope)
+
+	assert.NoError(t, checkWIP(graph, WIPConfig{}, graph.Tasks["tl-db2"], "alice", now))
+}
+
+func TestClaimEnforcesWIPLimits(t *testing.T) {
+	dir := seedWIPRepo(t)
+	anyTask := readyFilter{MaxPriority: -1}
+
+	_, err := claimTask(dir, claimRequest{ID: "tl-doc", Agent: "alice", Filter: anyTask})
+	assert.ErrorIs(t, err, ErrWIPLimit)
+
+	_, err = claimTask(dir, claimRequest{ID: "tl-db2", Agent: "bob", Filter: anyTask})
+	assert.ErrorIs(t, err, ErrWIPLimit)
+
+	result, err := claimTask(dir, claimRequest{Agent: "bob", Filter: anyTask})
+	require.NoError(t, err)
+	assert.Equal(t, "tl-doc", result.Issue.ID, "--next skips tasks a label limit rules out")
+
+	result, err = claimTask(dir, claimRequest{Agent: "carol", Filter: anyTask})
+	require.NoError(t, err)
+	assert.Equal(t, "tl-doc2", result.Issue.ID)
+
+	// Global limit of 3 is now reached.
+	_, err = claimTask(dir, claimRequest{Agent: "dave", Filter: anyTask})
+	var wipErr *WIPLimitError
+	require.ErrorAs(t, err, &wipErr)
+	assert.Equal(t, wipScopeGlobal, wipErr.Scope)
+}
+
+func TestClaimWIPErrorJSON(t *testing.T) {
+	dir := seedWIPRepo(t)
+	setCommandGlobals(t, dir, true)
+	prevAgent, prevNext := claimAgent, claimNext
+	t.Cleanup(func() { claimAgent, claimNext = prevAgent, prevNext })
+	claimAgent, claimNext = "alice", false
+
+	err := runClaim(newTestCommand(), []string{"tl-doc"})
+	require.ErrorIs(t, err, ErrWIPLimit)
+	assert.Equal(t, exitConflict, exitCode(err))
+
+	var buf bytes.Buffer
+	writeError(&buf, err, true)
+	var payload struct {
+		Error struct {
+			Code    string        `json:"code"`
+			Message string        `json:"message"`
+			Details WIPLimitError `json:"details"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &payload))
+	assert.Equal(t, codeWIPLimit, payload.Error.Code)
+	assert.Equal(t, err.Error(), payload.Error.Message)
+	assert.Equal(t, WIPLimitError{Scope: wipScopeAssignee, Key: "alice", Limit: 1, Current: 1}, payload.Error.Details)
+}
+
+func TestUpdateStatusEnforcesWIPLimits(t *testing.T) {
+	dir := seedWIPRepo(t)
+	setCommandGlobals(t, dir, false)
+
+	update := func(id string, flags map[string]string) error {
+		cmd := newTestCommand()
+		cmd.Flags().String("status", "", "")
+		cmd.Flags().String("assignee", "", "")
+		for name, value := range flags {
+			require.NoError(t, cmd.Flags().Set(name, value))
+		}
+		return runUpdate(cmd, []string{id})
+	}
+
+	err := update("tl-doc", map[string]string{"status": "in_progress", "assignee": "alice"})
+	assert.ErrorIs(t, err, ErrWIPLimit)
+	err = update("tl-db2", map[string]string{"status": "in_progress"})
+	assert.ErrorIs(t, err, ErrWIPLimit)
+
+	require.NoError(t, update("tl-doc", map[string]string{"status": "in_progress", "assignee": "bob"}))
+	require.NoError(t, update("tl-doc", map[string]string{"assignee": "bob"}), "keeping the assignee takes no new slot")
+	err = update("tl-doc", map[string]string{"assignee": "alice"})
+	assert.ErrorIs(t, err, ErrWIPLimit, "handing in-progress work over counts against the new assignee")
+	require.NoError(t, update("tl-doc2", map[string]string{"assignee": "alice"}), "open tasks may be assigned freely")
+}
+
+func TestEditReassignEnforcesWIPLimits(t *testing.T) {
+	dir := seedWIPRepo(t)
+	setCommandGlobals(t, dir, false)
+	_, err := claimTask(dir, claimRequest{ID: "tl-doc", Agent: "bob", Filter: readyFilter{MaxPriority: -1}})
+	require.NoError(t, err)
+
+	var path string
+	setEditor(t, &path, func(text string) string { return strings.Replace(text, "assignee: alice", "assignee: bob", 1) })
+	t.Cleanup(func() { os.Remove(path) })
+	err = runEdit(newTestCommand(), []string{"tl-db1"})
+	assert.ErrorIs(t, err, ErrWIPLimit)
+
+	graph, err := loadGraph(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", graph.Tasks["tl-db1"].Assignee)
+}
+
+func TestStatsShowsWIPUsage(t *testing.T) {
+	dir := seedWIPRepo(t)
+	setCommandGlobals(t, dir, false)
+
+	cmd := newTestCommand()
+	require.NoError(t, runStats(cmd, nil))
+	assert.Equal(t, "Open: 3 | In Progress: 1 | Blocked: 0 | Closed: 0 | Total: 4\n"+
+		"WIP global: 1/3\nWIP assignee alice: 1/1\nWIP label area:db: 1/1\n",
+		cmd.OutOrStdout().(*bytes.Buffer).String())
+
+	jsonOutput = true
+	cmd = newTestCommand()
+	require.NoError(t, runStats(cmd, nil))
+	var stats statsOutput
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(cmd.OutOrStdout().(*bytes.Buffer).Bytes()), &stats))
+	assert.Equal(t, []wipUsage{
+		{Scope: wipScopeGlobal, Limit: 3, Current: 1},
+		{Scope: wipScopeAssignee, Key: "alice", Limit: 1, Current: 1},
+		{Scope: wipScopeLabel, Key: "area:db", Limit: 1, Current: 1},
+	}, stats.WIP)
+}